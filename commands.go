@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services/audit_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/code_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/user_service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// runCommand executes a maintenance subcommand instead of starting the server.
+func runCommand(args []string, logger *zap.Logger, db *gorm.DB) error {
+	switch args[0] {
+	case "verify-audit":
+		return verifyAudit(logger, db)
+	case "import-icd10":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: import-icd10 <file.csv>")
+		}
+		return importICD10(logger, db, args[1])
+	case "create-admin":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: create-admin <username> (password on stdin)")
+		}
+		return createAdmin(logger, db, args[1])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func verifyAudit(logger *zap.Logger, db *gorm.DB) error {
+	auditService := audit_service.NewAuditService(repository.NewAuditRepository(db))
+
+	result, err := auditService.VerifyChain()
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		logger.Error("Audit chain broken",
+			zap.Uint("eventID", result.BrokenAt),
+			zap.String("reason", result.Reason),
+			zap.Int("checked", result.Checked))
+		return fmt.Errorf("audit chain broken at event %d: %s", result.BrokenAt, result.Reason)
+	}
+
+	logger.Info("Audit chain verified", zap.Int("checked", result.Checked))
+	return nil
+}
+
+func importICD10(logger *zap.Logger, db *gorm.DB, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	codeService := code_service.NewCodeService(repository.NewCodeRepository(db))
+
+	loaded, err := codeService.ImportICD10(file)
+	if err != nil {
+		return fmt.Errorf("import %s: %w", path, err)
+	}
+
+	logger.Info("ICD-10 catalog imported", zap.String("file", path), zap.Int("codes", loaded))
+	return nil
+}
+
+// createAdmin registers an admin account. Signup cannot create admins, so
+// this is the only way to make one. The password is read from the first line
+// of stdin to keep it out of the shell history.
+func createAdmin(logger *zap.Logger, db *gorm.DB, username string) error {
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return fmt.Errorf("read password: %w", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return fmt.Errorf("password is empty")
+	}
+
+	userService := user_service.NewUserService(repository.NewUserRepository(db))
+
+	user, err := userService.CreateAdmin(username, password)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Admin created", zap.String("username", user.Username), zap.Uint("userID", user.ID))
+	return nil
+}
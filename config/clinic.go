@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// ClinicConfig holds clinic-wide settings.
+type ClinicConfig struct {
+	// Location is the time zone doctors' working hours are written in.
+	Location *time.Location
+}
+
+// LoadClinicConfig reads CLINIC_TIMEZONE, an IANA zone name such as
+// "Asia/Kolkata". It defaults to UTC.
+func LoadClinicConfig() (*ClinicConfig, error) {
+	name := os.Getenv("CLINIC_TIMEZONE")
+	if name == "" {
+		name = "UTC"
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	return &ClinicConfig{Location: location}, nil
+}
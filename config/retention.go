@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultPatientRetentionDays keeps archived medical records for ten years.
+	defaultPatientRetentionDays = 3650
+	defaultRetentionInterval    = 24
+)
+
+// RetentionConfig holds the retention rules. A zero InactivePatientYears or
+// AuditLogRetentionYears disables that rule, and a zero Interval disables the
+// background scheduler.
+type RetentionConfig struct {
+	PatientRetention       time.Duration
+	InactivePatientYears   int
+	AuditLogRetentionYears int
+	Interval               time.Duration
+}
+
+// LoadRetentionConfig reads the retention settings:
+//   - PATIENT_RETENTION_DAYS: how long an archived patient is kept before it
+//     may be purged
+//   - INACTIVE_PATIENT_YEARS: inactivity after which a patient is anonymised
+//   - AUDIT_LOG_RETENTION_YEARS: age after which audit events are purged
+//   - RETENTION_INTERVAL_HOURS: how often the scheduler applies the rules
+func LoadRetentionConfig() *RetentionConfig {
+	return &RetentionConfig{
+		PatientRetention:       time.Duration(envInt("PATIENT_RETENTION_DAYS", defaultPatientRetentionDays)) * 24 * time.Hour,
+		InactivePatientYears:   envInt("INACTIVE_PATIENT_YEARS", 0),
+		AuditLogRetentionYears: envInt("AUDIT_LOG_RETENTION_YEARS", 0),
+		Interval:               time.Duration(envInt("RETENTION_INTERVAL_HOURS", defaultRetentionInterval)) * time.Hour,
+	}
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strings"
 	"time"
 
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
@@ -15,17 +16,44 @@ func UserToResponse(user *models.User) *response.UserResponse {
 		Role:     string(user.Role)}
 }
 
-func PatientToResponse(patient *models.Patient) *response.PatientResponse {
-	return &response.PatientResponse{
-		ID:             patient.ID,
-		FirstName:      patient.FirstName,
-		LastName:       patient.LastName,
-		Email:          patient.Email,
-		PhoneNumber:    patient.PhoneNumber,
-		Address:        patient.Address,
-		MedicalHistory: patient.MedicalHistory,
-		DOB:            patient.DOB,
+// UserToDocument renders the user attributes a patch may test or change.
+func UserToDocument(user *models.User) map[string]interface{} {
+	return map[string]interface{}{"username": user.Username}
+}
+
+// PatientUpdateToColumns converts the fields set on an update request to a
+// column-keyed map for the repository.
+func PatientUpdateToColumns(updateRequest *request.PatientUpdateRequest) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+	if updateRequest.FirstName != nil {
+		updates["first_name"] = *updateRequest.FirstName
+	}
+	if updateRequest.LastName != nil {
+		updates["last_name"] = *updateRequest.LastName
+	}
+	if updateRequest.DOB != nil {
+		dob, err := time.Parse("2006-01-02", *updateRequest.DOB)
+		if err != nil {
+			return nil, err
+		}
+		updates["dob"] = dob
+	}
+	if updateRequest.Email != nil {
+		updates["email"] = *updateRequest.Email
+	}
+	if updateRequest.Gender != nil {
+		updates["gender"] = *updateRequest.Gender
 	}
+	if updateRequest.PhoneNumber != nil {
+		updates["phone_number"] = *updateRequest.PhoneNumber
+	}
+	if updateRequest.Address != nil {
+		updates["address"] = *updateRequest.Address
+	}
+	if updateRequest.MedicalHistory != nil {
+		updates["medical_history"] = *updateRequest.MedicalHistory
+	}
+	return updates, nil
 }
 
 func UserToModel(userRequest *request.UserRequest) *models.User {
@@ -59,3 +87,760 @@ func PatientToModel(patientRequest *request.PatientRequest) (*models.Patient, er
 		MedicalHistory: patientRequest.MedicalHistory,
 	}, nil
 }
+
+func AppointmentToModel(appointmentRequest *request.AppointmentRequest) *models.Appointment {
+	return &models.Appointment{
+		PatientID: appointmentRequest.PatientID,
+		DoctorID:  appointmentRequest.DoctorID,
+		StartTime: appointmentRequest.StartTime,
+		EndTime:   appointmentRequest.EndTime,
+		Reason:    appointmentRequest.Reason,
+		Status:    models.AppointmentScheduled,
+	}
+}
+
+func AppointmentToResponse(appointment *models.Appointment) *response.AppointmentResponse {
+	return &response.AppointmentResponse{
+		ID:        appointment.ID,
+		PatientID: appointment.PatientID,
+		DoctorID:  appointment.DoctorID,
+		StartTime: appointment.StartTime,
+		EndTime:   appointment.EndTime,
+		Reason:    appointment.Reason,
+		Status:    string(appointment.Status),
+	}
+}
+
+func AppointmentsToResponse(appointments []models.Appointment) []*response.AppointmentResponse {
+	appointmentResponses := make([]*response.AppointmentResponse, 0, len(appointments))
+	for i := range appointments {
+		appointmentResponses = append(appointmentResponses, AppointmentToResponse(&appointments[i]))
+	}
+	return appointmentResponses
+}
+
+func WorkingHoursToModel(doctorID uint, workingHoursRequest *request.WorkingHoursRequest) []models.WorkingHours {
+	hours := make([]models.WorkingHours, 0, len(workingHoursRequest.Hours))
+	for _, entry := range workingHoursRequest.Hours {
+		hours = append(hours, models.WorkingHours{
+			DoctorID:  doctorID,
+			Weekday:   time.Weekday(*entry.Weekday),
+			StartTime: entry.StartTime,
+			EndTime:   entry.EndTime,
+		})
+	}
+	return hours
+}
+
+func WorkingHoursToResponse(hours []models.WorkingHours) []*response.WorkingHoursResponse {
+	hoursResponses := make([]*response.WorkingHoursResponse, 0, len(hours))
+	for _, h := range hours {
+		hoursResponses = append(hoursResponses, &response.WorkingHoursResponse{
+			ID:        h.ID,
+			DoctorID:  h.DoctorID,
+			Weekday:   int(h.Weekday),
+			StartTime: h.StartTime,
+			EndTime:   h.EndTime,
+		})
+	}
+	return hoursResponses
+}
+
+func AvailabilityExceptionToModel(doctorID uint, exceptionRequest *request.AvailabilityExceptionRequest) *models.AvailabilityException {
+	return &models.AvailabilityException{
+		DoctorID:  doctorID,
+		Kind:      models.ExceptionKind(exceptionRequest.Kind),
+		StartTime: exceptionRequest.StartTime,
+		EndTime:   exceptionRequest.EndTime,
+		Reason:    exceptionRequest.Reason,
+	}
+}
+
+func AvailabilityExceptionToResponse(exception *models.AvailabilityException) *response.AvailabilityExceptionResponse {
+	return &response.AvailabilityExceptionResponse{
+		ID:        exception.ID,
+		DoctorID:  exception.DoctorID,
+		Kind:      string(exception.Kind),
+		StartTime: exception.StartTime,
+		EndTime:   exception.EndTime,
+		Reason:    exception.Reason,
+	}
+}
+
+func AvailabilityExceptionsToResponse(exceptions []models.AvailabilityException) []*response.AvailabilityExceptionResponse {
+	exceptionResponses := make([]*response.AvailabilityExceptionResponse, 0, len(exceptions))
+	for i := range exceptions {
+		exceptionResponses = append(exceptionResponses, AvailabilityExceptionToResponse(&exceptions[i]))
+	}
+	return exceptionResponses
+}
+
+func AuditEventToResponse(event *models.AuditEvent) *response.AuditEventResponse {
+	return &response.AuditEventResponse{
+		ID:               event.ID,
+		ActorID:          event.ActorID,
+		Role:             event.Role,
+		Resource:         event.Resource,
+		PatientID:        event.PatientID,
+		ResourceID:       event.ResourceID,
+		ParentResourceID: event.ParentResourceID,
+		Action:           string(event.Action),
+		Fields:           event.Fields,
+		ClientIP:         event.ClientIP,
+		StatusCode:       event.StatusCode,
+		Timestamp:        event.CreatedAt,
+	}
+}
+
+func AuditEventsToResponse(events []models.AuditEvent) []*response.AuditEventResponse {
+	eventResponses := make([]*response.AuditEventResponse, 0, len(events))
+	for i := range events {
+		eventResponses = append(eventResponses, AuditEventToResponse(&events[i]))
+	}
+	return eventResponses
+}
+
+func RetentionRunToResponse(run *models.RetentionRun) *response.RetentionRunResponse {
+	results := make([]*response.RetentionRuleResultResponse, 0, len(run.Results))
+	for _, r := range run.Results {
+		results = append(results, &response.RetentionRuleResultResponse{
+			Rule:       r.Rule,
+			Action:     string(r.Action),
+			Cutoff:     r.Cutoff,
+			Affected:   r.Affected,
+			PatientIDs: r.PatientIDs,
+			Error:      r.Error,
+		})
+	}
+	return &response.RetentionRunResponse{
+		ID:          run.ID,
+		DryRun:      run.DryRun,
+		TriggeredBy: run.TriggeredBy,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		Results:     results,
+	}
+}
+
+func RetentionRunsToResponse(runs []models.RetentionRun) []*response.RetentionRunResponse {
+	runResponses := make([]*response.RetentionRunResponse, 0, len(runs))
+	for i := range runs {
+		runResponses = append(runResponses, RetentionRunToResponse(&runs[i]))
+	}
+	return runResponses
+}
+
+func ConditionToModel(patientID uint, conditionRequest *request.ConditionRequest, recordedBy string) (*models.Condition, error) {
+	var onsetDate *time.Time
+	if conditionRequest.OnsetDate != "" {
+		onset, err := time.Parse("2006-01-02", conditionRequest.OnsetDate)
+		if err != nil {
+			return nil, err
+		}
+		onsetDate = &onset
+	}
+
+	status := models.ConditionActive
+	if conditionRequest.Status != "" {
+		status = models.ConditionStatus(conditionRequest.Status)
+	}
+
+	return &models.Condition{
+		PatientID:   patientID,
+		Code:        conditionRequest.Code,
+		Description: conditionRequest.Description,
+		OnsetDate:   onsetDate,
+		Status:      status,
+		EncounterID: conditionRequest.EncounterID,
+		RecordedBy:  recordedBy,
+	}, nil
+}
+
+// ConditionUpdateToColumns converts the fields set on updateRequest into
+// column updates.
+func ConditionUpdateToColumns(updateRequest *request.ConditionUpdateRequest) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+	if updateRequest.Code != nil {
+		updates["code"] = *updateRequest.Code
+	}
+	if updateRequest.Description != nil {
+		updates["description"] = *updateRequest.Description
+	}
+	if updateRequest.OnsetDate != nil {
+		onset, err := time.Parse("2006-01-02", *updateRequest.OnsetDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["onset_date"] = onset
+	}
+	if updateRequest.Status != nil {
+		updates["status"] = models.ConditionStatus(*updateRequest.Status)
+	}
+	return updates, nil
+}
+
+func ConditionToResponse(condition *models.Condition) *response.ConditionResponse {
+	return &response.ConditionResponse{
+		ID:          condition.ID,
+		PatientID:   condition.PatientID,
+		Code:        condition.Code,
+		Description: condition.Description,
+		OnsetDate:   condition.OnsetDate,
+		Status:      string(condition.Status),
+		EncounterID: condition.EncounterID,
+		RecordedBy:  condition.RecordedBy,
+		CreatedAt:   condition.CreatedAt,
+		UpdatedAt:   condition.UpdatedAt,
+	}
+}
+
+func ConditionsToResponse(conditions []models.Condition) []*response.ConditionResponse {
+	conditionResponses := make([]*response.ConditionResponse, 0, len(conditions))
+	for i := range conditions {
+		conditionResponses = append(conditionResponses, ConditionToResponse(&conditions[i]))
+	}
+	return conditionResponses
+}
+
+func CodesToResponse(codes []models.ICD10Code) []*response.CodeResponse {
+	codeResponses := make([]*response.CodeResponse, 0, len(codes))
+	for _, code := range codes {
+		codeResponses = append(codeResponses, &response.CodeResponse{
+			Code:        code.Code,
+			Description: code.Description,
+		})
+	}
+	return codeResponses
+}
+
+func AllergyToModel(patientID uint, allergyRequest *request.AllergyRequest, recordedBy string) *models.Allergy {
+	verificationStatus := models.AllergyUnconfirmed
+	if allergyRequest.VerificationStatus != "" {
+		verificationStatus = models.AllergyVerificationStatus(allergyRequest.VerificationStatus)
+	}
+
+	return &models.Allergy{
+		PatientID:          patientID,
+		Substance:          strings.TrimSpace(allergyRequest.Substance),
+		Reaction:           allergyRequest.Reaction,
+		Severity:           models.AllergySeverity(allergyRequest.Severity),
+		VerificationStatus: verificationStatus,
+		RecordedBy:         recordedBy,
+	}
+}
+
+// AllergyUpdateToColumns converts the fields set on updateRequest into column
+// updates.
+func AllergyUpdateToColumns(updateRequest *request.AllergyUpdateRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if updateRequest.Substance != nil {
+		updates["substance"] = strings.TrimSpace(*updateRequest.Substance)
+	}
+	if updateRequest.Reaction != nil {
+		updates["reaction"] = *updateRequest.Reaction
+	}
+	if updateRequest.Severity != nil {
+		updates["severity"] = models.AllergySeverity(*updateRequest.Severity)
+	}
+	if updateRequest.VerificationStatus != nil {
+		updates["verification_status"] = models.AllergyVerificationStatus(*updateRequest.VerificationStatus)
+	}
+	return updates
+}
+
+func AllergyToResponse(allergy *models.Allergy) *response.AllergyResponse {
+	return &response.AllergyResponse{
+		ID:                 allergy.ID,
+		PatientID:          allergy.PatientID,
+		Substance:          allergy.Substance,
+		Reaction:           allergy.Reaction,
+		Severity:           string(allergy.Severity),
+		VerificationStatus: string(allergy.VerificationStatus),
+		RecordedBy:         allergy.RecordedBy,
+		CreatedAt:          allergy.CreatedAt,
+		UpdatedAt:          allergy.UpdatedAt,
+	}
+}
+
+func AllergiesToResponse(allergies []models.Allergy) []*response.AllergyResponse {
+	allergyResponses := make([]*response.AllergyResponse, 0, len(allergies))
+	for i := range allergies {
+		allergyResponses = append(allergyResponses, AllergyToResponse(&allergies[i]))
+	}
+	return allergyResponses
+}
+
+func PrescriptionToModel(patientID uint, prescriptionRequest *request.PrescriptionRequest, prescribedBy string) *models.Prescription {
+	return &models.Prescription{
+		PatientID:      patientID,
+		Drug:           strings.TrimSpace(prescriptionRequest.Drug),
+		Dose:           prescriptionRequest.Dose,
+		Route:          prescriptionRequest.Route,
+		Frequency:      prescriptionRequest.Frequency,
+		DurationDays:   prescriptionRequest.DurationDays,
+		Status:         models.PrescriptionDraft,
+		PrescribedBy:   prescribedBy,
+		OverrideReason: strings.TrimSpace(prescriptionRequest.OverrideReason),
+		EncounterID:    prescriptionRequest.EncounterID,
+	}
+}
+
+// PrescriptionUpdateToColumns converts the fields set on updateRequest into
+// column updates.
+func PrescriptionUpdateToColumns(updateRequest *request.PrescriptionUpdateRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if updateRequest.Drug != nil {
+		updates["drug"] = strings.TrimSpace(*updateRequest.Drug)
+	}
+	if updateRequest.Dose != nil {
+		updates["dose"] = *updateRequest.Dose
+	}
+	if updateRequest.Route != nil {
+		updates["route"] = *updateRequest.Route
+	}
+	if updateRequest.Frequency != nil {
+		updates["frequency"] = *updateRequest.Frequency
+	}
+	if updateRequest.DurationDays != nil {
+		updates["duration_days"] = *updateRequest.DurationDays
+	}
+	return updates
+}
+
+func PrescriptionToResponse(prescription *models.Prescription) *response.PrescriptionResponse {
+	return &response.PrescriptionResponse{
+		ID:                 prescription.ID,
+		PatientID:          prescription.PatientID,
+		EncounterID:        prescription.EncounterID,
+		Drug:               prescription.Drug,
+		Dose:               prescription.Dose,
+		Route:              prescription.Route,
+		Frequency:          prescription.Frequency,
+		DurationDays:       prescription.DurationDays,
+		Status:             string(prescription.Status),
+		PrescribedBy:       prescription.PrescribedBy,
+		SignedBy:           prescription.SignedBy,
+		SignedAt:           prescription.SignedAt,
+		DispensedBy:        prescription.DispensedBy,
+		DispensedAt:        prescription.DispensedAt,
+		DiscontinuedBy:     prescription.DiscontinuedBy,
+		DiscontinuedAt:     prescription.DiscontinuedAt,
+		DiscontinuedReason: prescription.DiscontinuedReason,
+		Warnings:           InteractionWarningsToResponse(prescription.InteractionWarnings),
+		OverrideReason:     prescription.OverrideReason,
+		CreatedAt:          prescription.CreatedAt,
+		UpdatedAt:          prescription.UpdatedAt,
+	}
+}
+
+func InteractionWarningsToResponse(warnings []models.InteractionWarning) []*response.InteractionWarningResponse {
+	warningResponses := make([]*response.InteractionWarningResponse, 0, len(warnings))
+	for _, warning := range warnings {
+		warningResponses = append(warningResponses, &response.InteractionWarningResponse{
+			Type:        string(warning.Type),
+			Severity:    string(warning.Severity),
+			Conflict:    warning.Conflict,
+			Description: warning.Description,
+		})
+	}
+	return warningResponses
+}
+
+func PrescriptionsToResponse(prescriptions []models.Prescription) []*response.PrescriptionResponse {
+	prescriptionResponses := make([]*response.PrescriptionResponse, 0, len(prescriptions))
+	for i := range prescriptions {
+		prescriptionResponses = append(prescriptionResponses, PrescriptionToResponse(&prescriptions[i]))
+	}
+	return prescriptionResponses
+}
+
+// VitalToModel converts vitalRequest into a reading, timestamped now unless
+// the request gives the time it was taken.
+func VitalToModel(patientID uint, vitalRequest *request.VitalRequest, recordedBy string) (*models.Vital, error) {
+	recordedAt := time.Now()
+	if vitalRequest.RecordedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, vitalRequest.RecordedAt)
+		if err != nil {
+			return nil, err
+		}
+		recordedAt = parsed
+	}
+
+	return &models.Vital{
+		PatientID:    patientID,
+		EncounterID:  vitalRequest.EncounterID,
+		SystolicBP:   vitalRequest.SystolicBP,
+		DiastolicBP:  vitalRequest.DiastolicBP,
+		HeartRate:    vitalRequest.HeartRate,
+		TemperatureC: vitalRequest.TemperatureC,
+		SpO2:         vitalRequest.SpO2,
+		WeightKg:     vitalRequest.WeightKg,
+		HeightCm:     vitalRequest.HeightCm,
+		RecordedAt:   recordedAt,
+		RecordedBy:   recordedBy,
+	}, nil
+}
+
+// VitalToResponse converts vital, attaching bmi when it could be computed.
+func VitalToResponse(vital *models.Vital, bmi *float64) *response.VitalResponse {
+	return &response.VitalResponse{
+		ID:           vital.ID,
+		PatientID:    vital.PatientID,
+		EncounterID:  vital.EncounterID,
+		SystolicBP:   vital.SystolicBP,
+		DiastolicBP:  vital.DiastolicBP,
+		HeartRate:    vital.HeartRate,
+		TemperatureC: vital.TemperatureC,
+		SpO2:         vital.SpO2,
+		WeightKg:     vital.WeightKg,
+		HeightCm:     vital.HeightCm,
+		BMI:          bmi,
+		Flags:        VitalFlagsToResponse(vital.Flags),
+		RecordedAt:   vital.RecordedAt,
+		RecordedBy:   vital.RecordedBy,
+	}
+}
+
+func VitalFlagsToResponse(flags []models.VitalFlag) []*response.VitalFlagResponse {
+	flagResponses := make([]*response.VitalFlagResponse, 0, len(flags))
+	for _, flag := range flags {
+		flagResponses = append(flagResponses, &response.VitalFlagResponse{
+			Measurement: flag.Measurement,
+			Value:       flag.Value,
+			Low:         flag.Low,
+			High:        flag.High,
+			RuleID:      flag.RuleID,
+			Description: flag.Description,
+		})
+	}
+	return flagResponses
+}
+
+func VitalRuleToModel(ruleRequest *request.VitalRuleRequest, updatedBy string) *models.VitalRule {
+	return &models.VitalRule{
+		Measurement: ruleRequest.Measurement,
+		MinAgeYears: ruleRequest.MinAgeYears,
+		MaxAgeYears: ruleRequest.MaxAgeYears,
+		Low:         ruleRequest.Low,
+		High:        ruleRequest.High,
+		Description: strings.TrimSpace(ruleRequest.Description),
+		UpdatedBy:   updatedBy,
+	}
+}
+
+// VitalRuleToColumns converts rule into the column updates that replace a
+// stored rule, including clearing thresholds and age limits left unset.
+func VitalRuleToColumns(rule *models.VitalRule) map[string]interface{} {
+	return map[string]interface{}{
+		"measurement":   rule.Measurement,
+		"min_age_years": rule.MinAgeYears,
+		"max_age_years": rule.MaxAgeYears,
+		"low":           rule.Low,
+		"high":          rule.High,
+		"description":   rule.Description,
+		"updated_by":    rule.UpdatedBy,
+	}
+}
+
+func VitalRuleToResponse(rule *models.VitalRule) *response.VitalRuleResponse {
+	return &response.VitalRuleResponse{
+		ID:          rule.ID,
+		Measurement: rule.Measurement,
+		MinAgeYears: rule.MinAgeYears,
+		MaxAgeYears: rule.MaxAgeYears,
+		Low:         rule.Low,
+		High:        rule.High,
+		Description: rule.Description,
+		UpdatedBy:   rule.UpdatedBy,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
+
+func VitalRulesToResponse(rules []models.VitalRule) []*response.VitalRuleResponse {
+	ruleResponses := make([]*response.VitalRuleResponse, 0, len(rules))
+	for i := range rules {
+		ruleResponses = append(ruleResponses, VitalRuleToResponse(&rules[i]))
+	}
+	return ruleResponses
+}
+
+func WorklistItemToResponse(item *models.WorklistItem) *response.WorklistItemResponse {
+	return &response.WorklistItemResponse{
+		ID:             item.ID,
+		DoctorID:       item.DoctorID,
+		PatientID:      item.PatientID,
+		Kind:           string(item.Kind),
+		SourceID:       item.SourceID,
+		Summary:        item.Summary,
+		Status:         string(item.Status),
+		AcknowledgedAt: item.AcknowledgedAt,
+		CreatedAt:      item.CreatedAt,
+	}
+}
+
+func WorklistItemsToResponse(items []models.WorklistItem) []*response.WorklistItemResponse {
+	itemResponses := make([]*response.WorklistItemResponse, 0, len(items))
+	for i := range items {
+		itemResponses = append(itemResponses, WorklistItemToResponse(&items[i]))
+	}
+	return itemResponses
+}
+
+func EncounterToModel(patientID uint, encounterRequest *request.EncounterRequest, createdBy string) (*models.Encounter, error) {
+	checkInAt := time.Now()
+	if encounterRequest.CheckInAt != "" {
+		parsed, err := time.Parse(time.RFC3339, encounterRequest.CheckInAt)
+		if err != nil {
+			return nil, err
+		}
+		checkInAt = parsed
+	}
+
+	encounterType := models.EncounterOffice
+	if encounterRequest.Type != "" {
+		encounterType = models.EncounterType(encounterRequest.Type)
+	}
+
+	return &models.Encounter{
+		PatientID:     patientID,
+		DoctorID:      encounterRequest.DoctorID,
+		AppointmentID: encounterRequest.AppointmentID,
+		Type:          encounterType,
+		Reason:        strings.TrimSpace(encounterRequest.Reason),
+		CheckInAt:     checkInAt,
+		CreatedBy:     createdBy,
+	}, nil
+}
+
+// EncounterToResponse converts encounter without its clinical sections,
+// which the caller fills in.
+func EncounterToResponse(encounter *models.Encounter) *response.EncounterResponse {
+	return &response.EncounterResponse{
+		ID:            encounter.ID,
+		PatientID:     encounter.PatientID,
+		DoctorID:      encounter.DoctorID,
+		AppointmentID: encounter.AppointmentID,
+		Type:          string(encounter.Type),
+		Reason:        encounter.Reason,
+		CheckInAt:     encounter.CheckInAt,
+		CheckOutAt:    encounter.CheckOutAt,
+		CreatedBy:     encounter.CreatedBy,
+	}
+}
+
+func NoteToModel(patientID, encounterID uint, noteRequest *request.NoteRequest, authorID string) *models.ClinicalNote {
+	return &models.ClinicalNote{
+		PatientID:   patientID,
+		EncounterID: encounterID,
+		Subjective:  strings.TrimSpace(noteRequest.Subjective),
+		Objective:   strings.TrimSpace(noteRequest.Objective),
+		Assessment:  strings.TrimSpace(noteRequest.Assessment),
+		Plan:        strings.TrimSpace(noteRequest.Plan),
+		Status:      models.NoteDraft,
+		AuthorID:    authorID,
+	}
+}
+
+// NoteUpdateToColumns converts the fields set on updateRequest into column
+// updates.
+func NoteUpdateToColumns(updateRequest *request.NoteUpdateRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if updateRequest.Subjective != nil {
+		updates["subjective"] = strings.TrimSpace(*updateRequest.Subjective)
+	}
+	if updateRequest.Objective != nil {
+		updates["objective"] = strings.TrimSpace(*updateRequest.Objective)
+	}
+	if updateRequest.Assessment != nil {
+		updates["assessment"] = strings.TrimSpace(*updateRequest.Assessment)
+	}
+	if updateRequest.Plan != nil {
+		updates["plan"] = strings.TrimSpace(*updateRequest.Plan)
+	}
+	return updates
+}
+
+func NoteToResponse(note *models.ClinicalNote) *response.NoteResponse {
+	return &response.NoteResponse{
+		ID:          note.ID,
+		PatientID:   note.PatientID,
+		EncounterID: note.EncounterID,
+		AddendumTo:  note.AddendumTo,
+		Subjective:  note.Subjective,
+		Objective:   note.Objective,
+		Assessment:  note.Assessment,
+		Plan:        note.Plan,
+		Status:      string(note.Status),
+		AuthorID:    note.AuthorID,
+		SignedBy:    note.SignedBy,
+		SignedAt:    note.SignedAt,
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+	}
+}
+
+func NotesToResponse(notes []models.ClinicalNote) []*response.NoteResponse {
+	noteResponses := make([]*response.NoteResponse, 0, len(notes))
+	for i := range notes {
+		noteResponses = append(noteResponses, NoteToResponse(&notes[i]))
+	}
+	return noteResponses
+}
+
+func LabOrderToModel(patientID uint, orderRequest *request.LabOrderRequest, orderingDoctorID uint) *models.LabOrder {
+	priority := models.LabPriority(orderRequest.Priority)
+	if priority == "" {
+		priority = models.LabRoutine
+	}
+
+	return &models.LabOrder{
+		PatientID:        patientID,
+		EncounterID:      orderRequest.EncounterID,
+		TestCode:         strings.ToUpper(strings.TrimSpace(orderRequest.TestCode)),
+		TestName:         strings.TrimSpace(orderRequest.TestName),
+		Priority:         priority,
+		OrderingDoctorID: orderingDoctorID,
+		Status:           models.LabOrdered,
+	}
+}
+
+// LabResultsToModel converts the reported results, flagging each from its
+// ranges unless the lab supplied a flag.
+func LabResultsToModel(patientID uint, resultsRequest *request.LabResultsRequest) []models.LabResult {
+	results := make([]models.LabResult, 0, len(resultsRequest.Results))
+	for _, resultRequest := range resultsRequest.Results {
+		result := models.LabResult{
+			PatientID:     patientID,
+			Analyte:       strings.TrimSpace(resultRequest.Analyte),
+			Value:         resultRequest.Value,
+			ValueText:     strings.TrimSpace(resultRequest.ValueText),
+			Unit:          strings.TrimSpace(resultRequest.Unit),
+			ReferenceLow:  resultRequest.ReferenceLow,
+			ReferenceHigh: resultRequest.ReferenceHigh,
+			CriticalLow:   resultRequest.CriticalLow,
+			CriticalHigh:  resultRequest.CriticalHigh,
+			Flag:          models.LabFlag(resultRequest.Flag),
+		}
+		if result.Flag == "" {
+			result.Flag = result.ComputeFlag()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// LabOrderToResponse converts order, leaving Results null unless
+// includeResults is set.
+func LabOrderToResponse(order *models.LabOrder, includeResults bool) *response.LabOrderResponse {
+	orderResponse := &response.LabOrderResponse{
+		ID:               order.ID,
+		PatientID:        order.PatientID,
+		EncounterID:      order.EncounterID,
+		TestCode:         order.TestCode,
+		TestName:         order.TestName,
+		Priority:         string(order.Priority),
+		OrderingDoctorID: order.OrderingDoctorID,
+		Status:           string(order.Status),
+		CollectedBy:      order.CollectedBy,
+		CollectedAt:      order.CollectedAt,
+		ResultedBy:       order.ResultedBy,
+		ResultedAt:       order.ResultedAt,
+		CancelledBy:      order.CancelledBy,
+		CancelledAt:      order.CancelledAt,
+		CancelReason:     order.CancelReason,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+	}
+	if !includeResults {
+		return orderResponse
+	}
+
+	orderResponse.Results = make([]*response.LabResultResponse, 0, len(order.Results))
+	for _, result := range order.Results {
+		orderResponse.Results = append(orderResponse.Results, &response.LabResultResponse{
+			ID:            result.ID,
+			Analyte:       result.Analyte,
+			Value:         result.Value,
+			ValueText:     result.ValueText,
+			Unit:          result.Unit,
+			ReferenceLow:  result.ReferenceLow,
+			ReferenceHigh: result.ReferenceHigh,
+			CriticalLow:   result.CriticalLow,
+			CriticalHigh:  result.CriticalHigh,
+			Flag:          string(result.Flag),
+		})
+	}
+	return orderResponse
+}
+
+func LabOrdersToResponse(orders []models.LabOrder, includeResults bool) []*response.LabOrderResponse {
+	orderResponses := make([]*response.LabOrderResponse, 0, len(orders))
+	for i := range orders {
+		orderResponses = append(orderResponses, LabOrderToResponse(&orders[i], includeResults))
+	}
+	return orderResponses
+}
+
+func ImmunizationToModel(patientID uint, immunizationRequest *request.ImmunizationRequest, administeredBy string) (*models.Immunization, error) {
+	administeredOn, err := time.Parse("2006-01-02", immunizationRequest.AdministeredOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Immunization{
+		PatientID:      patientID,
+		VaccineCode:    strings.TrimSpace(immunizationRequest.VaccineCode),
+		DoseNumber:     immunizationRequest.DoseNumber,
+		AdministeredOn: administeredOn,
+		LotNumber:      strings.TrimSpace(immunizationRequest.LotNumber),
+		Site:           models.InjectionSite(immunizationRequest.Site),
+		AdministeredBy: administeredBy,
+	}, nil
+}
+
+func ImmunizationToResponse(immunization *models.Immunization) *response.ImmunizationResponse {
+	return &response.ImmunizationResponse{
+		ID:             immunization.ID,
+		PatientID:      immunization.PatientID,
+		VaccineCode:    immunization.VaccineCode,
+		DoseNumber:     immunization.DoseNumber,
+		AdministeredOn: immunization.AdministeredOn.Format("2006-01-02"),
+		LotNumber:      immunization.LotNumber,
+		Site:           string(immunization.Site),
+		AdministeredBy: immunization.AdministeredBy,
+		CreatedAt:      immunization.CreatedAt,
+	}
+}
+
+func ImmunizationsToResponse(immunizations []models.Immunization) []*response.ImmunizationResponse {
+	immunizationResponses := make([]*response.ImmunizationResponse, 0, len(immunizations))
+	for i := range immunizations {
+		immunizationResponses = append(immunizationResponses, ImmunizationToResponse(&immunizations[i]))
+	}
+	return immunizationResponses
+}
+
+func ForecastToResponse(patientID uint, asOf time.Time, items []models.ForecastItem) *response.ImmunizationForecastResponse {
+	forecastResponse := &response.ImmunizationForecastResponse{
+		PatientID: patientID,
+		AsOf:      asOf.Format("2006-01-02"),
+		Items:     make([]*response.ForecastItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		itemResponse := &response.ForecastItemResponse{
+			VaccineCode: item.VaccineCode,
+			VaccineName: item.VaccineName,
+			DoseNumber:  item.DoseNumber,
+			Status:      string(item.Status),
+		}
+		if item.DueDate != nil {
+			itemResponse.DueDate = item.DueDate.Format("2006-01-02")
+		}
+		if item.OverdueDate != nil {
+			itemResponse.OverdueDate = item.OverdueDate.Format("2006-01-02")
+		}
+		forecastResponse.Items = append(forecastResponse.Items, itemResponse)
+	}
+	return forecastResponse
+}
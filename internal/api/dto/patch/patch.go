@@ -0,0 +1,455 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported patch media type")
+
+// Patch is a parsed patch document that can be applied to a JSON object.
+type Patch interface {
+	// Apply returns a patched copy of doc. doc itself is never modified and
+	// a failing operation leaves nothing half applied.
+	Apply(doc map[string]interface{}) (map[string]interface{}, error)
+	// Fields lists the top-level members the patch reads or writes.
+	Fields() []string
+}
+
+// InvalidPatchError is returned by Parse for patch documents that are not
+// well formed.
+type InvalidPatchError struct {
+	Reason string
+}
+
+func (e *InvalidPatchError) Error() string {
+	return "invalid patch: " + e.Reason
+}
+
+// TestFailedError is returned when a JSON Patch test operation does not match
+// the current document.
+type TestFailedError struct {
+	Path string
+}
+
+func (e *TestFailedError) Error() string {
+	return fmt.Sprintf("test failed at %q", e.Path)
+}
+
+// ApplyError is returned when a well formed patch cannot be applied to the
+// current document, e.g. because a path does not exist.
+type ApplyError struct {
+	Index  int
+	Path   string
+	Reason string
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("operation %d at %q: %s", e.Index, e.Path, e.Reason)
+}
+
+// Parse decodes body according to the patch media type in contentType.
+func Parse(contentType string, body []byte) (Patch, error) {
+	switch contentType {
+	case MergePatchMediaType:
+		return parseMergePatch(body)
+	case JSONPatchMediaType:
+		return parseJSONPatch(body)
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+}
+
+// Diff returns the top-level members whose values differ between before and
+// after. Members missing from after are reported with a nil value.
+func Diff(before, after map[string]interface{}) map[string]interface{} {
+	normalizedBefore, _ := normalize(before).(map[string]interface{})
+	normalizedAfter, _ := normalize(after).(map[string]interface{})
+
+	changes := map[string]interface{}{}
+	for key, value := range normalizedAfter {
+		if old, ok := normalizedBefore[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes[key] = value
+		}
+	}
+	for key := range normalizedBefore {
+		if _, ok := normalizedAfter[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	return changes
+}
+
+// MergePatch is an RFC 7396 JSON Merge Patch.
+type MergePatch struct {
+	patch map[string]interface{}
+}
+
+func parseMergePatch(body []byte) (*MergePatch, error) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, &InvalidPatchError{Reason: "merge patch must be a JSON object"}
+	}
+	return &MergePatch{patch: patch}, nil
+}
+
+func (p *MergePatch) Apply(doc map[string]interface{}) (map[string]interface{}, error) {
+	target, ok := normalize(doc).(map[string]interface{})
+	if !ok {
+		target = map[string]interface{}{}
+	}
+	return mergeObject(target, p.patch), nil
+}
+
+func (p *MergePatch) Fields() []string {
+	fields := make([]string, 0, len(p.patch))
+	for key := range p.patch {
+		fields = append(fields, key)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func mergeObject(target, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchChild, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+		targetChild, ok := target[key].(map[string]interface{})
+		if !ok {
+			targetChild = map[string]interface{}{}
+		}
+		target[key] = mergeObject(targetChild, patchChild)
+	}
+	return target
+}
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch is an RFC 6902 JSON Patch. Operations are applied in order and the
+// whole patch fails if any of them does.
+type JSONPatch struct {
+	operations []Operation
+	values     []interface{}
+	paths      [][]string
+	froms      [][]string
+}
+
+func parseJSONPatch(body []byte) (*JSONPatch, error) {
+	var operations []Operation
+	if err := json.Unmarshal(body, &operations); err != nil || operations == nil {
+		return nil, &InvalidPatchError{Reason: "JSON patch must be an array of operations"}
+	}
+
+	p := &JSONPatch{
+		operations: operations,
+		values:     make([]interface{}, len(operations)),
+		paths:      make([][]string, len(operations)),
+		froms:      make([][]string, len(operations)),
+	}
+	for i, op := range operations {
+		path, err := parsePointer(op.Path)
+		if err != nil {
+			return nil, &InvalidPatchError{Reason: fmt.Sprintf("operation %d: %s", i, err)}
+		}
+		p.paths[i] = path
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if len(op.Value) == 0 {
+				return nil, &InvalidPatchError{Reason: fmt.Sprintf("operation %d: %s requires a value", i, op.Op)}
+			}
+			if err := json.Unmarshal(op.Value, &p.values[i]); err != nil {
+				return nil, &InvalidPatchError{Reason: fmt.Sprintf("operation %d: invalid value", i)}
+			}
+		case "move", "copy":
+			from, err := parsePointer(op.From)
+			if err != nil {
+				return nil, &InvalidPatchError{Reason: fmt.Sprintf("operation %d: from: %s", i, err)}
+			}
+			p.froms[i] = from
+		case "remove":
+		default:
+			return nil, &InvalidPatchError{Reason: fmt.Sprintf("operation %d: unknown op %q", i, op.Op)}
+		}
+	}
+	return p, nil
+}
+
+func (p *JSONPatch) Apply(doc map[string]interface{}) (map[string]interface{}, error) {
+	var node interface{} = normalize(doc)
+
+	for i, op := range p.operations {
+		var err error
+		path := p.paths[i]
+
+		switch op.Op {
+		case "add":
+			node, err = add(node, path, normalize(p.values[i]))
+		case "remove":
+			node, _, err = remove(node, path)
+		case "replace":
+			if _, err = get(node, path); err == nil {
+				if len(path) == 0 {
+					node = normalize(p.values[i])
+				} else {
+					node, err = replace(node, path, normalize(p.values[i]))
+				}
+			}
+		case "move":
+			if isProperPrefix(p.froms[i], path) {
+				err = errors.New("cannot move a value into one of its children")
+				break
+			}
+			var value interface{}
+			if node, value, err = remove(node, p.froms[i]); err == nil {
+				node, err = add(node, path, value)
+			}
+		case "copy":
+			var value interface{}
+			if value, err = get(node, p.froms[i]); err == nil {
+				node, err = add(node, path, normalize(value))
+			}
+		case "test":
+			var value interface{}
+			value, err = get(node, path)
+			if err != nil || !reflect.DeepEqual(value, normalize(p.values[i])) {
+				return nil, &TestFailedError{Path: op.Path}
+			}
+		}
+		if err != nil {
+			return nil, &ApplyError{Index: i, Path: op.Path, Reason: err.Error()}
+		}
+	}
+
+	result, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, &ApplyError{Index: len(p.operations) - 1, Reason: "patched document is not an object"}
+	}
+	return result, nil
+}
+
+func (p *JSONPatch) Fields() []string {
+	seen := map[string]bool{}
+	for i := range p.operations {
+		for _, pointer := range [][]string{p.paths[i], p.froms[i]} {
+			if len(pointer) > 0 {
+				seen[pointer[0]] = true
+			}
+		}
+	}
+
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference
+// tokens. The empty pointer refers to the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func isProperPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			node = child
+		case []interface{}:
+			index, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q", token)
+		}
+	}
+	return node, nil
+}
+
+// add inserts value at path and returns the (possibly new) node.
+func add(node interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q does not exist", token)
+		}
+		updated, err := add(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []interface{}:
+		if len(rest) == 0 {
+			index := len(n)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			n = append(n, nil)
+			copy(n[index+1:], n[index:])
+			n[index] = value
+			return n, nil
+		}
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := add(n[index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot descend into %q", token)
+	}
+}
+
+// remove deletes the value at path, returning the updated node and the value
+// that was removed.
+func remove(node interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("member %q does not exist", token)
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := remove(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed := n[index]
+			return append(n[:index], n[index+1:]...), removed, nil
+		}
+		updated, removed, err := remove(n[index], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[index] = updated
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot descend into %q", token)
+	}
+}
+
+func replace(node interface{}, path []string, value interface{}) (interface{}, error) {
+	node, _, err := remove(node, path)
+	if err != nil {
+		return nil, err
+	}
+	return add(node, path, value)
+}
+
+// arrayIndex parses an array reference token, allowing indexes up to max.
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index := 0
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid array index %q", token)
+		}
+		index = index*10 + int(r-'0')
+		if index > max {
+			return 0, fmt.Errorf("array index %q out of range", token)
+		}
+	}
+	return index, nil
+}
+
+// normalize deep copies v through a JSON round trip so documents built from Go
+// values compare equal to decoded patch values.
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
@@ -0,0 +1,157 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func patientDocument() map[string]interface{} {
+	return map[string]interface{}{
+		"first_name": "Jane",
+		"last_name":  "Doe",
+		"email":      nil,
+		"tags":       []interface{}{"a", "b"},
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	p, err := Parse(MergePatchMediaType, []byte(`{"first_name":"Janet","last_name":null,"address":"Main Street"}`))
+	assert.NoError(t, err)
+
+	doc := patientDocument()
+	patched, err := p.Apply(doc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Janet", patched["first_name"])
+	assert.NotContains(t, patched, "last_name")
+	assert.Equal(t, "Main Street", patched["address"])
+	assert.Equal(t, "Jane", doc["first_name"], "input document must not be modified")
+	assert.Equal(t, []string{"address", "first_name", "last_name"}, p.Fields())
+}
+
+func TestMergePatch_RejectsNonObject(t *testing.T) {
+	_, err := Parse(MergePatchMediaType, []byte(`["first_name"]`))
+
+	var invalid *InvalidPatchError
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestJSONPatch_Operations(t *testing.T) {
+	body := `[
+		{"op":"test","path":"/first_name","value":"Jane"},
+		{"op":"replace","path":"/first_name","value":"Janet"},
+		{"op":"add","path":"/tags/1","value":"x"},
+		{"op":"add","path":"/tags/-","value":"z"},
+		{"op":"remove","path":"/tags/0"},
+		{"op":"copy","from":"/last_name","path":"/maiden_name"},
+		{"op":"move","from":"/last_name","path":"/surname"}
+	]`
+	p, err := Parse(JSONPatchMediaType, []byte(body))
+	assert.NoError(t, err)
+
+	patched, err := p.Apply(patientDocument())
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"first_name":  "Janet",
+		"email":       nil,
+		"tags":        []interface{}{"x", "b", "z"},
+		"maiden_name": "Doe",
+		"surname":     "Doe",
+	}, patched)
+	assert.Equal(t, []string{"first_name", "last_name", "maiden_name", "surname", "tags"}, p.Fields())
+}
+
+func TestJSONPatch_TestFailureAbortsPatch(t *testing.T) {
+	p, err := Parse(JSONPatchMediaType, []byte(`[
+		{"op":"replace","path":"/first_name","value":"Janet"},
+		{"op":"test","path":"/last_name","value":"Smith"}
+	]`))
+	assert.NoError(t, err)
+
+	doc := patientDocument()
+	patched, err := p.Apply(doc)
+
+	assert.Nil(t, patched)
+	var testErr *TestFailedError
+	assert.ErrorAs(t, err, &testErr)
+	assert.Equal(t, "/last_name", testErr.Path)
+	assert.Equal(t, "Jane", doc["first_name"])
+}
+
+func TestJSONPatch_TestNull(t *testing.T) {
+	p, err := Parse(JSONPatchMediaType, []byte(`[{"op":"test","path":"/email","value":null}]`))
+	assert.NoError(t, err)
+
+	_, err = p.Apply(patientDocument())
+
+	assert.NoError(t, err)
+}
+
+func TestJSONPatch_EscapedPointer(t *testing.T) {
+	p, err := Parse(JSONPatchMediaType, []byte(`[{"op":"add","path":"/a~1b~0c","value":1}]`))
+	assert.NoError(t, err)
+
+	patched, err := p.Apply(map[string]interface{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), patched["a/b~c"])
+}
+
+func TestJSONPatch_ApplyErrors(t *testing.T) {
+	cases := map[string]string{
+		"replace missing member": `[{"op":"replace","path":"/missing","value":"x"}]`,
+		"remove missing member":  `[{"op":"remove","path":"/missing"}]`,
+		"index out of range":     `[{"op":"add","path":"/tags/5","value":"x"}]`,
+		"leading zero index":     `[{"op":"remove","path":"/tags/01"}]`,
+		"move into child":        `[{"op":"move","from":"/tags","path":"/tags/0"}]`,
+		"replace root with list": `[{"op":"replace","path":"","value":[]}]`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := Parse(JSONPatchMediaType, []byte(body))
+			assert.NoError(t, err)
+
+			_, err = p.Apply(patientDocument())
+
+			var applyErr *ApplyError
+			assert.ErrorAs(t, err, &applyErr)
+		})
+	}
+}
+
+func TestJSONPatch_InvalidDocuments(t *testing.T) {
+	cases := map[string]string{
+		"not an array":  `{"op":"add"}`,
+		"unknown op":    `[{"op":"frobnicate","path":"/a"}]`,
+		"missing value": `[{"op":"add","path":"/a"}]`,
+		"bad pointer":   `[{"op":"remove","path":"a"}]`,
+		"bad from":      `[{"op":"copy","from":"a","path":"/b"}]`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(JSONPatchMediaType, []byte(body))
+
+			var invalid *InvalidPatchError
+			assert.ErrorAs(t, err, &invalid)
+		})
+	}
+}
+
+func TestParse_UnsupportedMediaType(t *testing.T) {
+	_, err := Parse("application/json", []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]interface{}{"first_name": "Jane", "email": "jane@example.com", "gender": "female"}
+	after := map[string]interface{}{"first_name": "Janet", "gender": "female", "address": "Main Street"}
+
+	assert.Equal(t, map[string]interface{}{
+		"first_name": "Janet",
+		"email":      nil,
+		"address":    "Main Street",
+	}, Diff(before, after))
+}
@@ -1,9 +1,11 @@
 package request
 
+import "time"
+
 type UserRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
-	Role     string `json:"role" binding:"required,oneof=doctor receptionist"`
+	Role     string `json:"role" binding:"required,oneof=doctor receptionist pharmacist"`
 }
 
 type PatientRequest struct {
@@ -12,12 +14,299 @@ type PatientRequest struct {
 	DOB            string `json:"dob" binding:"required,datetime=2006-01-02"`
 	Email          string `json:"email" binding:"omitempty,email"`
 	Gender         string `json:"gender" binding:"required"`
-	PhoneNumber    string `json:"phone_number" binding:"required"`
+	PhoneNumber    string `json:"phone_number" binding:"required,phone"`
 	Address        string `json:"address" binding:"required"`
-	MedicalHistory string `json:"medical_history" binding:"required"`
+	MedicalHistory string `json:"medical_history"`
+	AllowDuplicate bool   `json:"allow_duplicate"`
+}
+
+type PatientMergeRequest struct {
+	SourceID uint `json:"source_id" binding:"required"`
+}
+
+// PatientUpdateRequest lists the patient attributes a client may change. Nil
+// fields are left untouched.
+type PatientUpdateRequest struct {
+	FirstName      *string `json:"first_name,omitempty" binding:"omitempty,min=1,max=255"`
+	LastName       *string `json:"last_name,omitempty" binding:"omitempty,min=1,max=255"`
+	DOB            *string `json:"dob,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Email          *string `json:"email,omitempty" binding:"omitempty,email"`
+	Gender         *string `json:"gender,omitempty" binding:"omitempty,min=1,max=255"`
+	PhoneNumber    *string `json:"phone_number,omitempty" binding:"omitempty,phone"`
+	Address        *string `json:"address,omitempty" binding:"omitempty,min=1"`
+	MedicalHistory *string `json:"medical_history,omitempty"`
+}
+
+// UserUpdateRequest lists the user attributes a client may change. Role is
+// deliberately absent.
+type UserUpdateRequest struct {
+	Username *string `json:"username,omitempty" binding:"omitempty,min=3,max=50"`
+	Password *string `json:"password,omitempty" binding:"omitempty,min=8,max=72"`
 }
 
 type UserLoginRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type AppointmentRequest struct {
+	PatientID uint      `json:"patient_id" binding:"required"`
+	DoctorID  uint      `json:"doctor_id" binding:"required"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	Reason    string    `json:"reason"`
+}
+
+type RescheduleAppointmentRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+}
+
+type AppointmentQuery struct {
+	PatientID uint   `form:"patient_id"`
+	DoctorID  uint   `form:"doctor_id"`
+	Status    string `form:"status" binding:"omitempty,oneof=scheduled cancelled"`
+	From      string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type WorkingHoursEntry struct {
+	Weekday   *int   `json:"weekday" binding:"required,min=0,max=6"`
+	StartTime string `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime   string `json:"end_time" binding:"required,datetime=15:04"`
+}
+
+type WorkingHoursRequest struct {
+	Hours []WorkingHoursEntry `json:"hours" binding:"dive"`
+}
+
+type AvailabilityExceptionRequest struct {
+	Kind      string    `json:"kind" binding:"required,oneof=leave holiday"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	Reason    string    `json:"reason"`
+}
+
+type SlotQuery struct {
+	From     string `form:"from" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To       string `form:"to" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Duration int    `form:"duration" binding:"required,min=5,max=480"`
+}
+
+type PatientQuery struct {
+	Mode        string `form:"mode" binding:"omitempty,oneof=prefix fuzzy"`
+	Name        string `form:"name" binding:"required_if=Mode fuzzy"`
+	DOB         string `form:"dob" binding:"omitempty,datetime=2006-01-02"`
+	PhoneNumber string `form:"phone_number"`
+	Email       string `form:"email"`
+	Gender      string `form:"gender"`
+	CreatedFrom string `form:"created_from" binding:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `form:"created_to" binding:"omitempty,datetime=2006-01-02"`
+	Sort        string `form:"sort" binding:"omitempty,oneof=first_name last_name dob created_at"`
+	Order       string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Page        int    `form:"page" binding:"omitempty,min=1"`
+	PageSize    int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Archived    bool   `form:"archived"`
+}
+
+type AuditQuery struct {
+	PatientID uint   `form:"patient_id"`
+	UserID    string `form:"user_id"`
+	From      string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=500"`
+}
+
+type ConditionRequest struct {
+	Code        string `json:"code" binding:"required,max=10"`
+	Description string `json:"description"`
+	OnsetDate   string `json:"onset_date" binding:"omitempty,datetime=2006-01-02"`
+	Status      string `json:"status" binding:"omitempty,oneof=active resolved"`
+	EncounterID *uint  `json:"encounter_id"`
+}
+
+// ConditionUpdateRequest lists the condition attributes a client may change.
+// Nil fields are left untouched.
+type ConditionUpdateRequest struct {
+	Code        *string `json:"code,omitempty" binding:"omitempty,min=1,max=10"`
+	Description *string `json:"description,omitempty"`
+	OnsetDate   *string `json:"onset_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Status      *string `json:"status,omitempty" binding:"omitempty,oneof=active resolved"`
+}
+
+type ConditionQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=active resolved"`
+}
+
+type CodeQuery struct {
+	Q     string `form:"q" binding:"required,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type AllergyRequest struct {
+	Substance          string `json:"substance" binding:"required,max=255"`
+	Reaction           string `json:"reaction"`
+	Severity           string `json:"severity" binding:"required,oneof=mild moderate severe"`
+	VerificationStatus string `json:"verification_status" binding:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"`
+}
+
+// AllergyUpdateRequest lists the allergy attributes a client may change. Nil
+// fields are left untouched.
+type AllergyUpdateRequest struct {
+	Substance          *string `json:"substance,omitempty" binding:"omitempty,min=1,max=255"`
+	Reaction           *string `json:"reaction,omitempty"`
+	Severity           *string `json:"severity,omitempty" binding:"omitempty,oneof=mild moderate severe"`
+	VerificationStatus *string `json:"verification_status,omitempty" binding:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"`
+}
+
+type PrescriptionRequest struct {
+	Drug         string `json:"drug" binding:"required,max=255"`
+	Dose         string `json:"dose" binding:"required,max=100"`
+	Route        string `json:"route" binding:"required,oneof=oral sublingual buccal topical transdermal inhaled nasal ophthalmic otic rectal vaginal intravenous intramuscular subcutaneous"`
+	Frequency    string `json:"frequency" binding:"required,max=100"`
+	DurationDays *int   `json:"duration_days" binding:"omitempty,min=1,max=3650"`
+	// OverrideReason is required to accept high-severity interactions.
+	OverrideReason string `json:"override_reason"`
+	EncounterID    *uint  `json:"encounter_id"`
+}
+
+// PrescriptionUpdateRequest lists the attributes of a draft prescription a
+// client may change. Nil fields are left untouched.
+type PrescriptionUpdateRequest struct {
+	Drug         *string `json:"drug,omitempty" binding:"omitempty,min=1,max=255"`
+	Dose         *string `json:"dose,omitempty" binding:"omitempty,min=1,max=100"`
+	Route        *string `json:"route,omitempty" binding:"omitempty,oneof=oral sublingual buccal topical transdermal inhaled nasal ophthalmic otic rectal vaginal intravenous intramuscular subcutaneous"`
+	Frequency    *string `json:"frequency,omitempty" binding:"omitempty,min=1,max=100"`
+	DurationDays *int    `json:"duration_days,omitempty" binding:"omitempty,min=1,max=3650"`
+	// OverrideReason is required to accept high-severity interactions when
+	// the drug changes or before signing a draft that has them. Setting it
+	// re-checks the draft.
+	OverrideReason *string `json:"override_reason,omitempty"`
+}
+
+type DiscontinuePrescriptionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type PrescriptionQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft signed dispensed discontinued active"`
+}
+
+// VitalRequest is one set of measurements. Omitted measurements were not
+// taken; at least one must be present.
+type VitalRequest struct {
+	SystolicBP   *int     `json:"systolic_bp,omitempty" binding:"omitempty,min=40,max=300"`
+	DiastolicBP  *int     `json:"diastolic_bp,omitempty" binding:"omitempty,min=20,max=200"`
+	HeartRate    *int     `json:"heart_rate,omitempty" binding:"omitempty,min=20,max=300"`
+	TemperatureC *float64 `json:"temperature_c,omitempty" binding:"omitempty,min=25,max=45"`
+	SpO2         *int     `json:"spo2,omitempty" binding:"omitempty,min=50,max=100"`
+	WeightKg     *float64 `json:"weight_kg,omitempty" binding:"omitempty,gt=0,max=500"`
+	HeightCm     *float64 `json:"height_cm,omitempty" binding:"omitempty,min=20,max=280"`
+	// RecordedAt defaults to the time the request is received.
+	RecordedAt  string `json:"recorded_at,omitempty" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	EncounterID *uint  `json:"encounter_id,omitempty"`
+}
+
+type VitalQuery struct {
+	Type string `form:"type" binding:"omitempty,oneof=blood_pressure heart_rate temperature spo2 weight height bmi"`
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// VitalRuleRequest describes a threshold rule in full; updates replace every
+// attribute.
+type VitalRuleRequest struct {
+	Measurement string   `json:"measurement" binding:"required,oneof=systolic_bp diastolic_bp heart_rate temperature_c spo2 weight_kg height_cm bmi"`
+	MinAgeYears int      `json:"min_age_years" binding:"min=0,max=150"`
+	MaxAgeYears *int     `json:"max_age_years" binding:"omitempty,min=1,max=150"`
+	Low         *float64 `json:"low"`
+	High        *float64 `json:"high"`
+	Description string   `json:"description"`
+}
+
+type VitalRuleQuery struct {
+	Measurement string `form:"measurement" binding:"omitempty,oneof=systolic_bp diastolic_bp heart_rate temperature_c spo2 weight_kg height_cm bmi"`
+}
+
+type WorklistQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=open acknowledged"`
+}
+
+// EncounterRequest checks a patient in. With an appointment the doctor and
+// reason default to the booking's; without one DoctorID defaults to the
+// calling doctor.
+type EncounterRequest struct {
+	AppointmentID *uint  `json:"appointment_id"`
+	DoctorID      uint   `json:"doctor_id"`
+	Type          string `json:"type" binding:"omitempty,oneof=office telehealth home emergency"`
+	Reason        string `json:"reason"`
+	// CheckInAt defaults to the time the request is received.
+	CheckInAt string `json:"check_in_at" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// NoteRequest is a SOAP note or addendum. At least one section must be
+// written.
+type NoteRequest struct {
+	Subjective string `json:"subjective"`
+	Objective  string `json:"objective"`
+	Assessment string `json:"assessment"`
+	Plan       string `json:"plan"`
+}
+
+// NoteUpdateRequest lists the sections of a draft note a client may change.
+// Nil fields are left untouched.
+type NoteUpdateRequest struct {
+	Subjective *string `json:"subjective,omitempty"`
+	Objective  *string `json:"objective,omitempty"`
+	Assessment *string `json:"assessment,omitempty"`
+	Plan       *string `json:"plan,omitempty"`
+}
+
+// LabOrderRequest orders a test. Priority defaults to routine.
+type LabOrderRequest struct {
+	TestCode    string `json:"test_code" binding:"required,max=50"`
+	TestName    string `json:"test_name" binding:"max=255"`
+	Priority    string `json:"priority" binding:"omitempty,oneof=routine urgent stat"`
+	EncounterID *uint  `json:"encounter_id"`
+}
+
+type LabOrderQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=ordered collected resulted cancelled"`
+}
+
+type CancelLabOrderRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type LabResultsRequest struct {
+	Results []LabResultRequest `json:"results" binding:"required,min=1,dive"`
+}
+
+// LabResultRequest is one analyte as reported by the lab. Numeric results
+// are flagged against the ranges given; Flag overrides that and is the only
+// way to mark a text result abnormal.
+type LabResultRequest struct {
+	Analyte       string   `json:"analyte" binding:"required,max=100"`
+	Value         *float64 `json:"value"`
+	ValueText     string   `json:"value_text" binding:"max=255"`
+	Unit          string   `json:"unit" binding:"max=30"`
+	ReferenceLow  *float64 `json:"reference_low"`
+	ReferenceHigh *float64 `json:"reference_high"`
+	CriticalLow   *float64 `json:"critical_low"`
+	CriticalHigh  *float64 `json:"critical_high"`
+	Flag          string   `json:"flag" binding:"omitempty,oneof=normal low high abnormal critical_low critical_high"`
+}
+
+type ImmunizationRequest struct {
+	VaccineCode    string `json:"vaccine_code" binding:"required,max=20"`
+	DoseNumber     int    `json:"dose_number" binding:"required,min=1,max=20"`
+	AdministeredOn string `json:"administered_on" binding:"required,datetime=2006-01-02"`
+	LotNumber      string `json:"lot_number" binding:"required,max=50"`
+	Site           string `json:"site" binding:"required,oneof=left_arm right_arm left_thigh right_thigh left_gluteal right_gluteal oral intranasal"`
+}
+
+// ImmunizationForecastQuery dates the forecast; AsOf defaults to today.
+type ImmunizationForecastQuery struct {
+	AsOf string `form:"as_of" binding:"omitempty,datetime=2006-01-02"`
+}
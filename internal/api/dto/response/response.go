@@ -8,13 +8,349 @@ type UserResponse struct {
 	Role     string `json:"role"`
 }
 
+// PatientResponse omits every attribute the caller's role may not read.
 type PatientResponse struct {
+	ID             uint       `json:"id"`
+	FirstName      string     `json:"first_name,omitempty"`
+	LastName       string     `json:"last_name,omitempty"`
+	Email          *string    `json:"email,omitempty"`
+	Gender         string     `json:"gender,omitempty"`
+	PhoneNumber    string     `json:"phone_number,omitempty"`
+	Address        string     `json:"address,omitempty"`
+	MedicalHistory *string    `json:"medical_history,omitempty"`
+	DOB            *time.Time `json:"dob,omitempty"`
+	Version        uint       `json:"version"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+}
+
+type AppointmentResponse struct {
+	ID        uint      `json:"id"`
+	PatientID uint      `json:"patient_id"`
+	DoctorID  uint      `json:"doctor_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	Status    string    `json:"status"`
+}
+
+type WorkingHoursResponse struct {
+	ID        uint   `json:"id"`
+	DoctorID  uint   `json:"doctor_id"`
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type AvailabilityExceptionResponse struct {
+	ID        uint      `json:"id"`
+	DoctorID  uint      `json:"doctor_id"`
+	Kind      string    `json:"kind"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+type SlotResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+type PatientListResponse struct {
+	Patients []*PatientResponse `json:"patients"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
+
+type PatientMatchResponse struct {
+	*PatientResponse
+	Score float64 `json:"score"`
+}
+
+type PatientSearchResponse struct {
+	Matches  []*PatientMatchResponse `json:"matches"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+type AuditEventResponse struct {
+	ID               uint      `json:"id"`
+	ActorID          string    `json:"actor_id"`
+	Role             string    `json:"role"`
+	Resource         string    `json:"resource"`
+	PatientID        *uint     `json:"patient_id,omitempty"`
+	ResourceID       *uint     `json:"resource_id,omitempty"`
+	ParentResourceID *uint     `json:"parent_resource_id,omitempty"`
+	Action           string    `json:"action"`
+	Fields           []string  `json:"fields"`
+	ClientIP         string    `json:"client_ip"`
+	StatusCode       int       `json:"status_code"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+type AuditEventListResponse struct {
+	Events   []*AuditEventResponse `json:"events"`
+	Total    int64                 `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"page_size"`
+}
+
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type PatientVersionResponse struct {
+	Version    int                    `json:"version"`
+	ChangeType string                 `json:"change_type"`
+	ChangedBy  string                 `json:"changed_by,omitempty"`
+	ChangedAt  time.Time              `json:"changed_at"`
+	Changes    []*FieldChangeResponse `json:"changes"`
+}
+
+type RetentionRuleResultResponse struct {
+	Rule       string    `json:"rule"`
+	Action     string    `json:"action"`
+	Cutoff     time.Time `json:"cutoff"`
+	Affected   int64     `json:"affected"`
+	PatientIDs []uint    `json:"patient_ids,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+type RetentionRunResponse struct {
+	ID          uint                           `json:"id"`
+	DryRun      bool                           `json:"dry_run"`
+	TriggeredBy string                         `json:"triggered_by"`
+	StartedAt   time.Time                      `json:"started_at"`
+	FinishedAt  time.Time                      `json:"finished_at"`
+	Results     []*RetentionRuleResultResponse `json:"results"`
+}
+
+type ConditionResponse struct {
+	ID          uint       `json:"id"`
+	PatientID   uint       `json:"patient_id"`
+	Code        string     `json:"code"`
+	Description string     `json:"description"`
+	OnsetDate   *time.Time `json:"onset_date,omitempty"`
+	Status      string     `json:"status"`
+	EncounterID *uint      `json:"encounter_id,omitempty"`
+	RecordedBy  string     `json:"recorded_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CodeResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+type AllergyResponse struct {
+	ID                 uint      `json:"id"`
+	PatientID          uint      `json:"patient_id"`
+	Substance          string    `json:"substance"`
+	Reaction           string    `json:"reaction"`
+	Severity           string    `json:"severity"`
+	VerificationStatus string    `json:"verification_status"`
+	RecordedBy         string    `json:"recorded_by"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// PatientSummaryResponse is the at-a-glance view of a patient. Sections the
+// role may not see are null.
+type PatientSummaryResponse struct {
+	Patient          *PatientResponse        `json:"patient"`
+	Allergies        []*AllergyResponse      `json:"allergies"`
+	ActiveConditions []*ConditionResponse    `json:"active_conditions"`
+	Medications      []*PrescriptionResponse `json:"medications"`
+}
+
+type PrescriptionResponse struct {
+	ID                 uint                          `json:"id"`
+	PatientID          uint                          `json:"patient_id"`
+	EncounterID        *uint                         `json:"encounter_id,omitempty"`
+	Drug               string                        `json:"drug"`
+	Dose               string                        `json:"dose"`
+	Route              string                        `json:"route"`
+	Frequency          string                        `json:"frequency"`
+	DurationDays       *int                          `json:"duration_days,omitempty"`
+	Status             string                        `json:"status"`
+	PrescribedBy       string                        `json:"prescribed_by"`
+	SignedBy           string                        `json:"signed_by,omitempty"`
+	SignedAt           *time.Time                    `json:"signed_at,omitempty"`
+	DispensedBy        string                        `json:"dispensed_by,omitempty"`
+	DispensedAt        *time.Time                    `json:"dispensed_at,omitempty"`
+	DiscontinuedBy     string                        `json:"discontinued_by,omitempty"`
+	DiscontinuedAt     *time.Time                    `json:"discontinued_at,omitempty"`
+	DiscontinuedReason string                        `json:"discontinued_reason,omitempty"`
+	Warnings           []*InteractionWarningResponse `json:"warnings"`
+	OverrideReason     string                        `json:"override_reason,omitempty"`
+	CreatedAt          time.Time                     `json:"created_at"`
+	UpdatedAt          time.Time                     `json:"updated_at"`
+}
+
+type InteractionWarningResponse struct {
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	Conflict    string `json:"conflict"`
+	Description string `json:"description"`
+}
+
+type VitalResponse struct {
+	ID           uint                 `json:"id"`
+	PatientID    uint                 `json:"patient_id"`
+	EncounterID  *uint                `json:"encounter_id,omitempty"`
+	SystolicBP   *int                 `json:"systolic_bp,omitempty"`
+	DiastolicBP  *int                 `json:"diastolic_bp,omitempty"`
+	HeartRate    *int                 `json:"heart_rate,omitempty"`
+	TemperatureC *float64             `json:"temperature_c,omitempty"`
+	SpO2         *int                 `json:"spo2,omitempty"`
+	WeightKg     *float64             `json:"weight_kg,omitempty"`
+	HeightCm     *float64             `json:"height_cm,omitempty"`
+	BMI          *float64             `json:"bmi,omitempty"`
+	Flags        []*VitalFlagResponse `json:"flags"`
+	RecordedAt   time.Time            `json:"recorded_at"`
+	RecordedBy   string               `json:"recorded_by"`
+}
+
+type VitalFlagResponse struct {
+	Measurement string   `json:"measurement"`
+	Value       float64  `json:"value"`
+	Low         *float64 `json:"low,omitempty"`
+	High        *float64 `json:"high,omitempty"`
+	RuleID      uint     `json:"rule_id"`
+	Description string   `json:"description,omitempty"`
+}
+
+type VitalRuleResponse struct {
+	ID          uint      `json:"id"`
+	Measurement string    `json:"measurement"`
+	MinAgeYears int       `json:"min_age_years"`
+	MaxAgeYears *int      `json:"max_age_years"`
+	Low         *float64  `json:"low"`
+	High        *float64  `json:"high"`
+	Description string    `json:"description"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WorklistItemResponse struct {
+	ID             uint       `json:"id"`
+	DoctorID       uint       `json:"doctor_id"`
+	PatientID      uint       `json:"patient_id"`
+	Kind           string     `json:"kind"`
+	SourceID       uint       `json:"source_id"`
+	Summary        string     `json:"summary"`
+	Status         string     `json:"status"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// EncounterResponse is one visit with the clinical data recorded during it.
+// Sections the role may not see are null.
+type EncounterResponse struct {
+	ID            uint                    `json:"id"`
+	PatientID     uint                    `json:"patient_id"`
+	DoctorID      uint                    `json:"doctor_id"`
+	AppointmentID *uint                   `json:"appointment_id,omitempty"`
+	Type          string                  `json:"type"`
+	Reason        string                  `json:"reason"`
+	CheckInAt     time.Time               `json:"check_in_at"`
+	CheckOutAt    *time.Time              `json:"check_out_at,omitempty"`
+	CreatedBy     string                  `json:"created_by"`
+	Vitals        []*VitalResponse        `json:"vitals"`
+	Notes         []*NoteResponse         `json:"notes"`
+	Conditions    []*ConditionResponse    `json:"conditions"`
+	Prescriptions []*PrescriptionResponse `json:"prescriptions"`
+	LabOrders     []*LabOrderResponse     `json:"lab_orders"`
+}
+
+type NoteResponse struct {
+	ID          uint       `json:"id"`
+	PatientID   uint       `json:"patient_id"`
+	EncounterID uint       `json:"encounter_id"`
+	AddendumTo  *uint      `json:"addendum_to,omitempty"`
+	Subjective  string     `json:"subjective"`
+	Objective   string     `json:"objective"`
+	Assessment  string     `json:"assessment"`
+	Plan        string     `json:"plan"`
+	Status      string     `json:"status"`
+	AuthorID    string     `json:"author_id"`
+	SignedBy    string     `json:"signed_by,omitempty"`
+	SignedAt    *time.Time `json:"signed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NoteHistoryResponse is a signed note followed by its addenda in the order
+// they were written.
+type NoteHistoryResponse struct {
+	Note    *NoteResponse   `json:"note"`
+	Addenda []*NoteResponse `json:"addenda"`
+}
+
+// LabOrderResponse is a lab order. Results is null for roles that may only
+// follow the order's status.
+type LabOrderResponse struct {
+	ID               uint                 `json:"id"`
+	PatientID        uint                 `json:"patient_id"`
+	EncounterID      *uint                `json:"encounter_id,omitempty"`
+	TestCode         string               `json:"test_code"`
+	TestName         string               `json:"test_name,omitempty"`
+	Priority         string               `json:"priority"`
+	OrderingDoctorID uint                 `json:"ordering_doctor_id"`
+	Status           string               `json:"status"`
+	CollectedBy      string               `json:"collected_by,omitempty"`
+	CollectedAt      *time.Time           `json:"collected_at,omitempty"`
+	ResultedBy       string               `json:"resulted_by,omitempty"`
+	ResultedAt       *time.Time           `json:"resulted_at,omitempty"`
+	CancelledBy      string               `json:"cancelled_by,omitempty"`
+	CancelledAt      *time.Time           `json:"cancelled_at,omitempty"`
+	CancelReason     string               `json:"cancel_reason,omitempty"`
+	Results          []*LabResultResponse `json:"results"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+type LabResultResponse struct {
+	ID            uint     `json:"id"`
+	Analyte       string   `json:"analyte"`
+	Value         *float64 `json:"value,omitempty"`
+	ValueText     string   `json:"value_text,omitempty"`
+	Unit          string   `json:"unit,omitempty"`
+	ReferenceLow  *float64 `json:"reference_low,omitempty"`
+	ReferenceHigh *float64 `json:"reference_high,omitempty"`
+	CriticalLow   *float64 `json:"critical_low,omitempty"`
+	CriticalHigh  *float64 `json:"critical_high,omitempty"`
+	Flag          string   `json:"flag"`
+}
+
+type ImmunizationResponse struct {
 	ID             uint      `json:"id"`
-	FirstName      string    `json:"first_name"`
-	LastName       string    `json:"last_name"`
-	Email          *string   `json:"email,omitempty"`
-	PhoneNumber    string    `json:"phone_number"`
-	Address        string    `json:"address"`
-	MedicalHistory string    `json:"medical_history"`
-	DOB            time.Time `json:"dob"`
+	PatientID      uint      `json:"patient_id"`
+	VaccineCode    string    `json:"vaccine_code"`
+	DoseNumber     int       `json:"dose_number"`
+	AdministeredOn string    `json:"administered_on"`
+	LotNumber      string    `json:"lot_number"`
+	Site           string    `json:"site"`
+	AdministeredBy string    `json:"administered_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ImmunizationForecastResponse struct {
+	PatientID uint                    `json:"patient_id"`
+	AsOf      string                  `json:"as_of"`
+	Items     []*ForecastItemResponse `json:"items"`
+}
+
+type ForecastItemResponse struct {
+	VaccineCode string `json:"vaccine_code"`
+	VaccineName string `json:"vaccine_name"`
+	DoseNumber  int    `json:"dose_number"`
+	Status      string `json:"status"`
+	DueDate     string `json:"due_date,omitempty"`
+	OverdueDate string `json:"overdue_date,omitempty"`
 }
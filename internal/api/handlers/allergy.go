@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CreateAllergy(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "allergy", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var allergyRequest request.AllergyRequest
+	if err := c.ShouldBindJSON(&allergyRequest); err != nil {
+		h.logger.Error("Failed to bind allergy request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(allergyRequest)
+
+	allergy, err := h.allergyService.CreateAllergy(idParam, &allergyRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondAllergyError(c, err, "record", idParam)
+		return
+	}
+
+	h.logger.Info("Allergy recorded successfully", zap.String("patientID", idParam), zap.Uint("allergyID", allergy.ID))
+	c.JSON(http.StatusCreated, gin.H{"allergy": allergy})
+}
+
+func (h *Handler) ListAllergies(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "allergy", models.AuditList, parsePatientID(idParam), nil) }()
+
+	allergies, err := h.allergyService.ListAllergies(idParam, role)
+	if err != nil {
+		h.respondAllergyError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"allergies": allergies})
+}
+
+func (h *Handler) GetAllergyById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "allergy", models.AuditView, parsePatientID(idParam), nil) }()
+
+	allergy, err := h.allergyService.GetAllergyById(idParam, c.Param("allergyId"), role)
+	if err != nil {
+		h.respondAllergyError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"allergy": allergy})
+}
+
+func (h *Handler) UpdateAllergyById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "allergy", models.AuditUpdate, parsePatientID(idParam), fields) }()
+
+	var updateRequest request.AllergyUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
+		h.logger.Error("Failed to bind allergy update request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(updateRequest)
+
+	allergy, err := h.allergyService.UpdateAllergyById(idParam, c.Param("allergyId"), &updateRequest, role)
+	if err != nil {
+		h.respondAllergyError(c, err, "update", idParam)
+		return
+	}
+
+	h.logger.Info("Allergy updated successfully", zap.String("patientID", idParam), zap.Uint("allergyID", allergy.ID))
+	c.JSON(200, gin.H{"allergy": allergy})
+}
+
+func (h *Handler) DeleteAllergyById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "allergy", models.AuditDelete, parsePatientID(idParam), nil) }()
+
+	if err := h.allergyService.DeleteAllergyById(idParam, c.Param("allergyId"), role); err != nil {
+		h.respondAllergyError(c, err, "delete", idParam)
+		return
+	}
+
+	h.logger.Info("Allergy deleted successfully", zap.String("patientID", idParam), zap.String("allergyID", c.Param("allergyId")))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondAllergyError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid allergy ID", "no fields to update":
+		h.logger.Error("Invalid allergy request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" allergy", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this allergy"})
+	case "patient not found", "allergy not found":
+		h.logger.Error("Allergy lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" allergy", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " allergy"})
+	}
+}
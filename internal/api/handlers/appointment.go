@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) BookAppointment(c *gin.Context) {
+	var appointmentRequest request.AppointmentRequest
+	role := c.GetString("role")
+
+	if err := c.ShouldBindJSON(&appointmentRequest); err != nil {
+		h.logger.Error("Failed to bind appointment request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	appointmentResponse, err := h.appointmentService.BookAppointment(&appointmentRequest, role)
+	if err != nil {
+		h.respondAppointmentError(c, err, "book", "")
+		return
+	}
+
+	h.logger.Info("Appointment booked successfully", zap.String("appointmentID", fmt.Sprint(appointmentResponse.ID)))
+	c.JSON(http.StatusCreated, gin.H{"appointment": appointmentResponse})
+}
+
+func (h *Handler) RescheduleAppointment(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var rescheduleRequest request.RescheduleAppointmentRequest
+	if err := c.ShouldBindJSON(&rescheduleRequest); err != nil {
+		h.logger.Error("Failed to bind reschedule request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	appointmentResponse, err := h.appointmentService.RescheduleAppointment(idParam, &rescheduleRequest, role)
+	if err != nil {
+		h.respondAppointmentError(c, err, "reschedule", idParam)
+		return
+	}
+
+	h.logger.Info("Appointment rescheduled successfully", zap.String("appointmentID", idParam))
+	c.JSON(200, gin.H{"appointment": appointmentResponse})
+}
+
+func (h *Handler) CancelAppointment(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	appointmentResponse, err := h.appointmentService.CancelAppointment(idParam, role)
+	if err != nil {
+		h.respondAppointmentError(c, err, "cancel", idParam)
+		return
+	}
+
+	h.logger.Info("Appointment cancelled successfully", zap.String("appointmentID", idParam))
+	c.JSON(200, gin.H{"appointment": appointmentResponse})
+}
+
+func (h *Handler) GetAppointmentById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+	userID := c.GetString("user_id")
+
+	appointmentResponse, err := h.appointmentService.GetAppointmentById(idParam, role, userID)
+	if err != nil {
+		h.respondAppointmentError(c, err, "view", idParam)
+		return
+	}
+
+	h.logger.Info("Appointment retrieved successfully", zap.String("appointmentID", idParam))
+	c.JSON(200, gin.H{"appointment": appointmentResponse})
+}
+
+func (h *Handler) ListAppointments(c *gin.Context) {
+	role := c.GetString("role")
+	userID := c.GetString("user_id")
+
+	var query request.AppointmentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind appointment query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	appointments, err := h.appointmentService.ListAppointments(&query, role, userID)
+	if err != nil {
+		h.respondAppointmentError(c, err, "list", "")
+		return
+	}
+
+	c.JSON(200, gin.H{"appointments": appointments})
+}
+
+func (h *Handler) respondAppointmentError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid appointment ID", "invalid time range", "invalid user ID":
+		h.logger.Error("Invalid appointment request", zap.String("appointmentID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" appointment", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this appointment"})
+	case "appointment not found", "patient not found", "doctor not found":
+		h.logger.Error("Appointment lookup failed", zap.String("appointmentID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "time slot already booked", "appointment is cancelled", "outside working hours", "doctor unavailable":
+		h.logger.Warn("Appointment conflict", zap.String("appointmentID", idParam), zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" appointment", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " appointment"})
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/patient_service"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) ArchivePatient(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditPatientAccess(c, models.AuditArchive, parsePatientID(idParam), nil) }()
+
+	patientResponse, err := h.patientService.ArchivePatient(idParam, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondArchiveError(c, err, "archive")
+		return
+	}
+
+	h.logger.Info("Patient archived successfully", zap.String("patientID", idParam))
+	c.JSON(200, gin.H{"patient": patientResponse})
+}
+
+func (h *Handler) RestorePatient(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditPatientAccess(c, models.AuditRestore, parsePatientID(idParam), nil) }()
+
+	patientResponse, err := h.patientService.RestorePatient(idParam, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondArchiveError(c, err, "restore")
+		return
+	}
+
+	h.logger.Info("Patient restored successfully", zap.String("patientID", idParam))
+	c.JSON(200, gin.H{"patient": patientResponse})
+}
+
+func (h *Handler) PurgePatient(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditPatientAccess(c, models.AuditPurge, parsePatientID(idParam), nil) }()
+
+	err := h.patientService.PurgePatient(idParam, role)
+	if err != nil {
+		var retentionErr *patient_service.RetentionPeriodError
+		if errors.As(err, &retentionErr) {
+			h.logger.Warn("Patient still within retention period", zap.String("patientID", idParam), zap.Time("purgeableAt", retentionErr.PurgeableAt))
+			c.JSON(http.StatusConflict, gin.H{"error": "Patient is still within its retention period", "purgeable_at": retentionErr.PurgeableAt})
+			return
+		}
+		h.respondArchiveError(c, err, "purge")
+		return
+	}
+
+	h.logger.Info("Patient purged successfully", zap.String("patientID", idParam))
+	c.JSON(200, gin.H{"message": "Patient purged successfully"})
+}
+
+// respondArchiveError writes the response for the errors shared by archive,
+// restore and purge.
+func (h *Handler) respondArchiveError(c *gin.Context, err error, action string) {
+	idParam := c.Param("id")
+
+	switch err.Error() {
+	case "invalid patient ID":
+		h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
+		c.JSON(400, gin.H{"error": "Invalid patient ID"})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" patient", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this patient"})
+	case "patient not found", "archived patient not found":
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "email already in use":
+		h.logger.Warn("Patient email conflict", zap.String("patientID", idParam))
+		c.JSON(409, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" patient", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " patient"})
+	}
+}
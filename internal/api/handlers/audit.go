@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	role := c.GetString("role")
+
+	var query request.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind audit query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	events, err := h.auditService.ListAuditEvents(&query, role)
+	if err != nil {
+		if err.Error() == "invalid time range" {
+			h.logger.Error("Invalid audit query", zap.Error(err))
+			c.JSON(400, gin.H{"error": "Invalid time range"})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to view audit log", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to view the audit log"})
+			return
+		}
+		h.logger.Error("Failed to list audit events", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to list audit events"})
+		return
+	}
+
+	c.JSON(200, events)
+}
+
+// auditPatientAccess records a PHI access once the handler has written its
+// response, so denied and failed attempts are logged with their status too.
+// It is meant to be deferred at the top of each patient handler.
+func (h *Handler) auditPatientAccess(c *gin.Context, action models.AuditAction, patientID *uint, fields []string) {
+	h.auditAccess(c, "patient", action, patientID, fields)
+}
+
+// auditAccess is auditPatientAccess for records a patient owns, such as their
+// conditions, allergies and prescriptions.
+func (h *Handler) auditAccess(c *gin.Context, resource string, action models.AuditAction, patientID *uint, fields []string) {
+	h.auditRecordAccess(c, resource, action, patientID, nil, nil, fields)
+}
+
+// auditRecordAccess is auditAccess naming the record accessed and, for
+// records that amend another such as note addenda, the record amended.
+func (h *Handler) auditRecordAccess(c *gin.Context, resource string, action models.AuditAction, patientID, resourceID, parentResourceID *uint, fields []string) {
+	event := &models.AuditEvent{
+		ActorID:          c.GetString("user_id"),
+		Role:             c.GetString("role"),
+		Resource:         resource,
+		PatientID:        patientID,
+		ResourceID:       resourceID,
+		ParentResourceID: parentResourceID,
+		Action:           action,
+		Fields:           fields,
+		ClientIP:         c.ClientIP(),
+		StatusCode:       c.Writer.Status(),
+	}
+
+	if err := h.auditService.Record(event); err != nil {
+		h.logger.Error("Failed to record audit event", zap.String("action", string(action)), zap.Error(err))
+	}
+}
+
+func parsePatientID(idParam string) *uint {
+	return parseResourceID(idParam)
+}
+
+func parseResourceID(idParam string) *uint {
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return nil
+	}
+	resourceID := uint(id)
+	return &resourceID
+}
+
+// fieldNames returns the sorted JSON keys present in v.
+func fieldNames(v any) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
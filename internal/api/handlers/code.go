@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) SearchICD10Codes(c *gin.Context) {
+	role := c.GetString("role")
+
+	var query request.CodeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind code query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	codes, err := h.codeService.SearchICD10(&query, role)
+	if err != nil {
+		if err.Error() == "search term is required" {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to search codes", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to search codes"})
+			return
+		}
+		h.logger.Error("Failed to search ICD-10 codes", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to search codes"})
+		return
+	}
+
+	c.JSON(200, gin.H{"codes": codes})
+}
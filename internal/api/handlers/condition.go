@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CreateCondition(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "condition", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var conditionRequest request.ConditionRequest
+	if err := c.ShouldBindJSON(&conditionRequest); err != nil {
+		h.logger.Error("Failed to bind condition request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(conditionRequest)
+
+	condition, err := h.conditionService.CreateCondition(idParam, &conditionRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondConditionError(c, err, "record", idParam)
+		return
+	}
+
+	h.logger.Info("Condition recorded successfully", zap.String("patientID", idParam), zap.Uint("conditionID", condition.ID))
+	c.JSON(http.StatusCreated, gin.H{"condition": condition})
+}
+
+func (h *Handler) ListConditions(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "condition", models.AuditList, parsePatientID(idParam), nil) }()
+
+	var query request.ConditionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind condition query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	conditions, err := h.conditionService.ListConditions(idParam, &query, role)
+	if err != nil {
+		h.respondConditionError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"conditions": conditions})
+}
+
+func (h *Handler) GetConditionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "condition", models.AuditView, parsePatientID(idParam), nil) }()
+
+	condition, err := h.conditionService.GetConditionById(idParam, c.Param("conditionId"), role)
+	if err != nil {
+		h.respondConditionError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"condition": condition})
+}
+
+func (h *Handler) UpdateConditionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "condition", models.AuditUpdate, parsePatientID(idParam), fields) }()
+
+	var updateRequest request.ConditionUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
+		h.logger.Error("Failed to bind condition update request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(updateRequest)
+
+	condition, err := h.conditionService.UpdateConditionById(idParam, c.Param("conditionId"), &updateRequest, role)
+	if err != nil {
+		h.respondConditionError(c, err, "update", idParam)
+		return
+	}
+
+	h.logger.Info("Condition updated successfully", zap.String("patientID", idParam), zap.Uint("conditionID", condition.ID))
+	c.JSON(200, gin.H{"condition": condition})
+}
+
+func (h *Handler) DeleteConditionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "condition", models.AuditDelete, parsePatientID(idParam), nil) }()
+
+	if err := h.conditionService.DeleteConditionById(idParam, c.Param("conditionId"), role); err != nil {
+		h.respondConditionError(c, err, "delete", idParam)
+		return
+	}
+
+	h.logger.Info("Condition deleted successfully", zap.String("patientID", idParam), zap.String("conditionID", c.Param("conditionId")))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondConditionError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid condition ID", "invalid ICD-10 code", "unknown ICD-10 code", "invalid onset date", "no fields to update":
+		h.logger.Error("Invalid condition request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" condition", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this condition"})
+	case "patient not found", "condition not found", "encounter not found":
+		h.logger.Error("Condition lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" condition", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " condition"})
+	}
+}
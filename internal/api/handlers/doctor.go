@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) SetWorkingHours(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+	userID := c.GetString("user_id")
+
+	var workingHoursRequest request.WorkingHoursRequest
+	if err := c.ShouldBindJSON(&workingHoursRequest); err != nil {
+		h.logger.Error("Failed to bind working hours request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	hours, err := h.availabilityService.SetWorkingHours(idParam, &workingHoursRequest, role, userID)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "update working hours")
+		return
+	}
+
+	h.logger.Info("Working hours updated successfully", zap.String("doctorID", idParam))
+	c.JSON(200, gin.H{"working_hours": hours})
+}
+
+func (h *Handler) GetWorkingHours(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	hours, err := h.availabilityService.GetWorkingHours(idParam, role)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "view working hours")
+		return
+	}
+
+	c.JSON(200, gin.H{"working_hours": hours})
+}
+
+func (h *Handler) AddAvailabilityException(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+	userID := c.GetString("user_id")
+
+	var exceptionRequest request.AvailabilityExceptionRequest
+	if err := c.ShouldBindJSON(&exceptionRequest); err != nil {
+		h.logger.Error("Failed to bind availability exception request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	exception, err := h.availabilityService.AddException(idParam, &exceptionRequest, role, userID)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "add availability exception")
+		return
+	}
+
+	h.logger.Info("Availability exception added successfully", zap.String("doctorID", idParam))
+	c.JSON(http.StatusCreated, gin.H{"exception": exception})
+}
+
+func (h *Handler) ListAvailabilityExceptions(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	exceptions, err := h.availabilityService.ListExceptions(idParam, role)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "view availability exceptions")
+		return
+	}
+
+	c.JSON(200, gin.H{"exceptions": exceptions})
+}
+
+func (h *Handler) DeleteAvailabilityException(c *gin.Context) {
+	idParam := c.Param("id")
+	exceptionIdParam := c.Param("exceptionId")
+	role := c.GetString("role")
+	userID := c.GetString("user_id")
+
+	err := h.availabilityService.DeleteException(idParam, exceptionIdParam, role, userID)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "delete availability exception")
+		return
+	}
+
+	h.logger.Info("Availability exception deleted successfully", zap.String("doctorID", idParam), zap.String("exceptionID", exceptionIdParam))
+	c.JSON(200, gin.H{"message": "Availability exception deleted successfully"})
+}
+
+func (h *Handler) GetFreeSlots(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var slotQuery request.SlotQuery
+	if err := c.ShouldBindQuery(&slotQuery); err != nil {
+		h.logger.Error("Failed to bind slot query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	slots, err := h.availabilityService.GetFreeSlots(idParam, &slotQuery, role)
+	if err != nil {
+		h.respondAvailabilityError(c, err, "search slots")
+		return
+	}
+
+	c.JSON(200, gin.H{"slots": slots})
+}
+
+func (h *Handler) respondAvailabilityError(c *gin.Context, err error, action string) {
+	switch err.Error() {
+	case "invalid doctor ID", "invalid exception ID", "invalid time range":
+		h.logger.Error("Invalid availability request", zap.String("doctorID", c.Param("id")), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action, zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action})
+	case "doctor not found", "exception not found":
+		h.logger.Error("Availability lookup failed", zap.String("doctorID", c.Param("id")), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action, zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action})
+	}
+}
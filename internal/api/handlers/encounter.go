@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CheckInEncounter(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "encounter", models.AuditCheckIn, parsePatientID(idParam), fields) }()
+
+	var encounterRequest request.EncounterRequest
+	if err := c.ShouldBindJSON(&encounterRequest); err != nil {
+		h.logger.Error("Failed to bind encounter request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(encounterRequest)
+
+	encounter, err := h.encounterService.CheckIn(idParam, &encounterRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondEncounterError(c, err, "check in", idParam)
+		return
+	}
+
+	h.logger.Info("Patient checked in", zap.String("patientID", idParam), zap.Uint("encounterID", encounter.ID))
+	c.JSON(http.StatusCreated, gin.H{"encounter": encounter})
+}
+
+func (h *Handler) CheckOutEncounter(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "encounter", models.AuditCheckOut, parsePatientID(idParam), nil) }()
+
+	encounter, err := h.encounterService.CheckOut(idParam, c.Param("encounterId"), role)
+	if err != nil {
+		h.respondEncounterError(c, err, "check out", idParam)
+		return
+	}
+
+	h.logger.Info("Patient checked out", zap.String("patientID", idParam), zap.Uint("encounterID", encounter.ID))
+	c.JSON(200, gin.H{"encounter": encounter})
+}
+
+func (h *Handler) ListEncounters(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "encounter", models.AuditList, parsePatientID(idParam), nil) }()
+
+	encounters, err := h.encounterService.ListEncounters(idParam, role)
+	if err != nil {
+		h.respondEncounterError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"encounters": encounters})
+}
+
+func (h *Handler) GetEncounterById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "encounter", models.AuditView, parsePatientID(idParam), nil) }()
+
+	encounter, err := h.encounterService.GetEncounterById(idParam, c.Param("encounterId"), role)
+	if err != nil {
+		h.respondEncounterError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"encounter": encounter})
+}
+
+func (h *Handler) respondEncounterError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid encounter ID", "invalid check_in_at", "check_in_at is in the future", "doctor_id is required":
+		h.logger.Error("Invalid encounter request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" encounter", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this encounter"})
+	case "patient not found", "encounter not found", "appointment not found", "doctor not found":
+		h.logger.Error("Encounter lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "encounter already checked out", "appointment already checked in", "appointment is cancelled":
+		h.logger.Warn("Encounter conflict", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(409, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" encounter", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " encounter"})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/services/patient_service"
+	"go.uber.org/zap"
+)
+
+// patientETag is the strong entity tag for a patient version.
+func patientETag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// requireIfMatch reads the If-Match header into the patient versions it
+// names. Weak or malformed tags never match. A missing header, or "*", which
+// would skip the version check, is answered with 428.
+func (h *Handler) requireIfMatch(c *gin.Context) ([]uint, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return nil, false
+	}
+	if header == "*" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match must name the patient version being updated"})
+		return nil, false
+	}
+
+	versions := []uint{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || len(tag) < 2 {
+			continue
+		}
+		version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, uint(version))
+	}
+	return versions, true
+}
+
+// respondPreconditionFailed answers a failed If-Match check with 412 and the
+// current ETag, and reports whether err was such a failure.
+func (h *Handler) respondPreconditionFailed(c *gin.Context, err error) bool {
+	var preconditionErr *patient_service.PreconditionFailedError
+	if !errors.As(err, &preconditionErr) {
+		return false
+	}
+	h.logger.Info("Patient version mismatch", zap.String("patientID", c.Param("id")), zap.Uint("currentVersion", preconditionErr.CurrentVersion))
+	c.Header("ETag", patientETag(preconditionErr.CurrentVersion))
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Patient was modified by someone else", "current_version": preconditionErr.CurrentVersion})
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using the
+// weak comparison RFC 9110 prescribes for that header.
+func etagMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,38 +1,102 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 	"github.com/palashbhasme/healthcare-portal/config"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
 	"github.com/palashbhasme/healthcare-portal/internal/api/middleware"
 	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"github.com/palashbhasme/healthcare-portal/internal/services/allergy_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/appointment_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/audit_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/availability_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/code_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/condition_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/encounter_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/immunization_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/lab_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/note_service"
 	"github.com/palashbhasme/healthcare-portal/internal/services/patient_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/prescription_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/retention_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/summary_service"
 	"github.com/palashbhasme/healthcare-portal/internal/services/user_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/vital_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/worklist_service"
 	"go.uber.org/zap"
 )
 
 type Handler struct {
-	userService    *user_service.UserService
-	patientService *patient_service.PatientService
-	logger         *zap.Logger
-	auth           *config.AuthConfig
+	userService         *user_service.UserService
+	patientService      *patient_service.PatientService
+	appointmentService  *appointment_service.AppointmentService
+	availabilityService *availability_service.AvailabilityService
+	auditService        *audit_service.AuditService
+	retentionService    *retention_service.RetentionService
+	conditionService    *condition_service.ConditionService
+	codeService         *code_service.CodeService
+	allergyService      *allergy_service.AllergyService
+	summaryService      *summary_service.SummaryService
+	prescriptionService *prescription_service.PrescriptionService
+	vitalService        *vital_service.VitalService
+	worklistService     *worklist_service.WorklistService
+	encounterService    *encounter_service.EncounterService
+	noteService         *note_service.NoteService
+	labService          *lab_service.LabService
+	immunizationService *immunization_service.ImmunizationService
+	logger              *zap.Logger
+	auth                *config.AuthConfig
 }
 
 func NewHandler(router *gin.Engine, logger *zap.Logger,
 	userService *user_service.UserService,
 	patientService *patient_service.PatientService,
+	appointmentService *appointment_service.AppointmentService,
+	availabilityService *availability_service.AvailabilityService,
+	auditService *audit_service.AuditService,
+	retentionService *retention_service.RetentionService,
+	conditionService *condition_service.ConditionService,
+	codeService *code_service.CodeService,
+	allergyService *allergy_service.AllergyService,
+	summaryService *summary_service.SummaryService,
+	prescriptionService *prescription_service.PrescriptionService,
+	vitalService *vital_service.VitalService,
+	worklistService *worklist_service.WorklistService,
+	encounterService *encounter_service.EncounterService,
+	noteService *note_service.NoteService,
+	labService *lab_service.LabService,
+	immunizationService *immunization_service.ImmunizationService,
 	auth *config.AuthConfig) {
 
 	handler := &Handler{
-		userService:    userService,
-		patientService: patientService,
-		logger:         logger,
-		auth:           auth,
+		userService:         userService,
+		patientService:      patientService,
+		appointmentService:  appointmentService,
+		availabilityService: availabilityService,
+		auditService:        auditService,
+		retentionService:    retentionService,
+		conditionService:    conditionService,
+		codeService:         codeService,
+		allergyService:      allergyService,
+		summaryService:      summaryService,
+		prescriptionService: prescriptionService,
+		vitalService:        vitalService,
+		worklistService:     worklistService,
+		encounterService:    encounterService,
+		noteService:         noteService,
+		labService:          labService,
+		immunizationService: immunizationService,
+		logger:              logger,
+		auth:                auth,
 	}
 
 	api := router.Group("/api")
@@ -43,6 +107,7 @@ func NewHandler(router *gin.Engine, logger *zap.Logger,
 			user.POST("/login", handler.LoginUser)
 
 			user.PUT("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdateUserById)
+			user.PATCH("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.PatchUserById)
 			user.DELETE("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeleteUserById)
 		}
 
@@ -51,7 +116,124 @@ func NewHandler(router *gin.Engine, logger *zap.Logger,
 			// Patient routes
 			patient.POST("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreatePatient)
 			patient.PUT("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdatePatientById)
+			patient.PATCH("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.PatchPatientById)
+			patient.GET("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListPatients)
 			patient.GET("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetPatientById)
+			patient.GET("/:id/history", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetPatientHistory)
+			patient.GET("/:id/summary", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetPatientSummary)
+			patient.POST("/:id/merge", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.MergePatient)
+			patient.POST("/:id/archive", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ArchivePatient)
+			patient.POST("/:id/restore", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.RestorePatient)
+			patient.DELETE("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.PurgePatient)
+
+			// Problem list routes
+			patient.GET("/:id/conditions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListConditions)
+			patient.POST("/:id/conditions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreateCondition)
+			patient.GET("/:id/conditions/:conditionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetConditionById)
+			patient.PUT("/:id/conditions/:conditionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdateConditionById)
+			patient.DELETE("/:id/conditions/:conditionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeleteConditionById)
+
+			// Allergy routes
+			patient.GET("/:id/allergies", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListAllergies)
+			patient.POST("/:id/allergies", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreateAllergy)
+			patient.GET("/:id/allergies/:allergyId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetAllergyById)
+			patient.PUT("/:id/allergies/:allergyId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdateAllergyById)
+			patient.DELETE("/:id/allergies/:allergyId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeleteAllergyById)
+
+			// Prescription routes
+			patient.GET("/:id/prescriptions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListPrescriptions)
+			patient.POST("/:id/prescriptions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreatePrescription)
+			patient.GET("/:id/prescriptions/:prescriptionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetPrescriptionById)
+			patient.PUT("/:id/prescriptions/:prescriptionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdatePrescriptionById)
+			patient.DELETE("/:id/prescriptions/:prescriptionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeletePrescriptionById)
+			patient.POST("/:id/prescriptions/:prescriptionId/sign", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.SignPrescription)
+			patient.POST("/:id/prescriptions/:prescriptionId/dispense", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DispensePrescription)
+			patient.POST("/:id/prescriptions/:prescriptionId/discontinue", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DiscontinuePrescription)
+
+			// Encounter routes
+			patient.GET("/:id/encounters", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListEncounters)
+			patient.POST("/:id/encounters", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CheckInEncounter)
+			patient.GET("/:id/encounters/:encounterId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetEncounterById)
+			patient.POST("/:id/encounters/:encounterId/check-out", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CheckOutEncounter)
+
+			// Clinical note routes
+			patient.GET("/:id/encounters/:encounterId/notes", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListEncounterNotes)
+			patient.POST("/:id/encounters/:encounterId/notes", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreateNote)
+			patient.GET("/:id/notes/:noteId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetNoteById)
+			patient.PUT("/:id/notes/:noteId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdateNoteById)
+			patient.POST("/:id/notes/:noteId/sign", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.SignNote)
+			patient.POST("/:id/notes/:noteId/addenda", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.AddNoteAddendum)
+			patient.GET("/:id/notes/:noteId/history", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetNoteHistory)
+
+			// Lab order routes
+			patient.GET("/:id/lab-orders", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListLabOrders)
+			patient.POST("/:id/lab-orders", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreateLabOrder)
+			patient.GET("/:id/lab-orders/:orderId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetLabOrderById)
+			patient.POST("/:id/lab-orders/:orderId/collect", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CollectLabOrder)
+			patient.POST("/:id/lab-orders/:orderId/results", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.RecordLabResults)
+			patient.POST("/:id/lab-orders/:orderId/cancel", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CancelLabOrder)
+
+			// Immunization routes
+			patient.GET("/:id/immunizations", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListImmunizations)
+			patient.POST("/:id/immunizations", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.RecordImmunization)
+			patient.GET("/:id/immunizations/forecast", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetImmunizationForecast)
+
+			// Vitals routes
+			patient.GET("/:id/vitals", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListVitals)
+			patient.POST("/:id/vitals", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.RecordVital)
+		}
+
+		appointment := api.Group("/appointment")
+		{
+			// Appointment routes
+			appointment.POST("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.BookAppointment)
+			appointment.GET("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListAppointments)
+			appointment.GET("/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetAppointmentById)
+			appointment.PUT("/:id/reschedule", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.RescheduleAppointment)
+			appointment.PUT("/:id/cancel", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CancelAppointment)
+		}
+
+		doctor := api.Group("/doctor")
+		{
+			// Doctor availability routes
+			doctor.GET("/:id/hours", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetWorkingHours)
+			doctor.PUT("/:id/hours", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.SetWorkingHours)
+			doctor.GET("/:id/exceptions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListAvailabilityExceptions)
+			doctor.POST("/:id/exceptions", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.AddAvailabilityException)
+			doctor.DELETE("/:id/exceptions/:exceptionId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeleteAvailabilityException)
+			doctor.GET("/:id/slots", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.GetFreeSlots)
+
+			// Worklist routes
+			doctor.GET("/:id/worklist", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListWorklist)
+			doctor.POST("/:id/worklist/:itemId/acknowledge", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.AcknowledgeWorklistItem)
+		}
+
+		audit := api.Group("/audit")
+		{
+			// Audit log routes (admin only)
+			audit.GET("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListAuditEvents)
+		}
+
+		codes := api.Group("/codes")
+		{
+			// Code catalog routes
+			codes.GET("/icd10", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.SearchICD10Codes)
+		}
+
+		vitalRules := api.Group("/vital-rules")
+		{
+			// Abnormal vitals threshold routes
+			vitalRules.GET("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListVitalRules)
+			vitalRules.POST("/", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.CreateVitalRule)
+			vitalRules.PUT("/:ruleId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.UpdateVitalRuleById)
+			vitalRules.DELETE("/:ruleId", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DeleteVitalRuleById)
+		}
+
+		retention := api.Group("/retention")
+		{
+			// Retention routes
+			retention.GET("/runs", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.ListRetentionRuns)
+			retention.POST("/dry-run", middleware.AuthMiddleware(), middleware.RoleMiddleware(), handler.DryRunRetention)
 		}
 	}
 }
@@ -130,19 +312,30 @@ func (h *Handler) LoginUser(c *gin.Context) {
 
 func (h *Handler) UpdateUserById(c *gin.Context) {
 	idParam := c.Param("id")
+	role := c.GetString("role")
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var updateRequest request.UserUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
 		h.logger.Error("Failed to bind user update request", zap.Error(err))
 		c.JSON(400, gin.H{"error": "Invalid request"})
 		return
 	}
 
-	userResponse, err := h.userService.UpdateUserById(idParam, updates)
+	userResponse, err := h.userService.UpdateUserById(idParam, &updateRequest, role, c.GetString("user_id"))
 	if err != nil {
-		if err.Error() == "invalid user ID" {
-			h.logger.Error("Invalid user ID", zap.String("userID", idParam))
-			c.JSON(400, gin.H{"error": "Invalid user ID"})
+		if err.Error() == "invalid user ID" || err.Error() == "no fields to update" {
+			h.logger.Error("Invalid user update", zap.String("userID", idParam), zap.Error(err))
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to update user", zap.String("userID", idParam))
+			c.JSON(403, gin.H{"error": "You do not have permission to update this user"})
+			return
+		}
+		if err.Error() == "user already exists" {
+			h.logger.Error("User already exists", zap.String("userID", idParam))
+			c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
 			return
 		}
 		h.logger.Error("Failed to update user", zap.Error(err))
@@ -177,15 +370,38 @@ func (h *Handler) UpdatePatientById(c *gin.Context) {
 	idParam := c.Param("id")
 	role := c.GetString("role")
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditUpdate, parsePatientID(idParam), fields) }()
+
+	ifMatch, ok := h.requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var updateRequest request.PatientUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
 		h.logger.Error("Failed to bind patient update request", zap.Error(err))
 		c.JSON(400, gin.H{"error": "Invalid request"})
 		return
 	}
+	fields = fieldNames(updateRequest)
 
-	patientResponse, err := h.patientService.UpdatePatientById(idParam, updates, role)
+	patientResponse, err := h.patientService.UpdatePatientById(idParam, &updateRequest, ifMatch, role, c.GetString("user_id"))
 	if err != nil {
+		if h.respondPreconditionFailed(c, err) {
+			return
+		}
+		if err.Error() == "invalid date of birth" || err.Error() == "no fields to update" {
+			h.logger.Error("Invalid patient update", zap.String("patientID", idParam), zap.Error(err))
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		var fieldErr *services.FieldAccessError
+		if errors.As(err, &fieldErr) {
+			h.logger.Warn("Field access denied", zap.String("role", role), zap.Strings("fields", fieldErr.Fields))
+			c.JSON(403, gin.H{"error": "You do not have permission to modify these fields", "fields": fieldErr.Fields})
+			return
+		}
 		if err.Error() == "invalid patient ID" {
 			h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
 			c.JSON(400, gin.H{"error": "Invalid patient ID"})
@@ -196,12 +412,22 @@ func (h *Handler) UpdatePatientById(c *gin.Context) {
 			c.JSON(403, gin.H{"error": "You do not have permission to update this patient"})
 			return
 		}
+		if err.Error() == "patient not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Patient not found"})
+			return
+		}
+		if err.Error() == "email already in use" {
+			h.logger.Warn("Patient email conflict", zap.String("patientID", idParam))
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to update patient", zap.Error(err))
 		c.JSON(500, gin.H{"error": "Failed to update patient"})
 		return
 	}
 
 	h.logger.Info("Patient updated successfully", zap.String("patientID", idParam))
+	c.Header("ETag", patientETag(patientResponse.Version))
 	c.JSON(200, gin.H{"patient": patientResponse})
 }
 
@@ -209,8 +435,39 @@ func (h *Handler) GetPatientById(c *gin.Context) {
 	idParam := c.Param("id")
 	role := c.GetString("role")
 
-	patientResponse, err := h.patientService.GetPatientById(idParam, role)
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditView, parsePatientID(idParam), fields) }()
+
+	var patientResponse *response.PatientResponse
+	var err error
+	if asOf := c.Query("as_of"); asOf != "" {
+		patientResponse, err = h.patientService.GetPatientAsOf(idParam, asOf, role)
+	} else {
+		patientResponse, err = h.patientService.GetPatientById(idParam, role)
+	}
 	if err != nil {
+		if err.Error() == "invalid as_of timestamp" {
+			h.logger.Error("Invalid as_of timestamp", zap.String("patientID", idParam))
+			c.JSON(400, gin.H{"error": "Invalid as_of timestamp"})
+			return
+		}
+		if err.Error() == "patient archived" {
+			h.logger.Info("Patient is archived", zap.String("patientID", idParam))
+			c.JSON(http.StatusGone, gin.H{"error": "Patient is archived"})
+			return
+		}
+		if err.Error() == "no patient version at that time" {
+			h.logger.Warn("No patient version for as_of", zap.String("patientID", idParam), zap.String("asOf", c.Query("as_of")))
+			c.JSON(404, gin.H{"error": "No version of this patient exists at that time"})
+			return
+		}
+		var mergedErr *patient_service.PatientMergedError
+		if errors.As(err, &mergedErr) {
+			h.logger.Info("Patient was merged", zap.String("patientID", idParam), zap.Uint("targetID", mergedErr.TargetID))
+			c.Header("Location", fmt.Sprintf("/api/patient/%d", mergedErr.TargetID))
+			c.JSON(http.StatusMovedPermanently, gin.H{"merged_into": mergedErr.TargetID})
+			return
+		}
 		if err.Error() == "invalid patient ID" {
 			h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
 			c.JSON(400, gin.H{"error": "Invalid patient ID"})
@@ -226,32 +483,229 @@ func (h *Handler) GetPatientById(c *gin.Context) {
 		return
 	}
 
+	// the body is redacted per role, so shared caches must never keep it
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Vary", "token")
+	if c.Query("as_of") == "" {
+		etag := patientETag(patientResponse.Version)
+		c.Header("ETag", etag)
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	fields = fieldNames(patientResponse)
 	h.logger.Info("Patient retrieved successfully", zap.String("patientID", idParam))
 	c.JSON(200, gin.H{"patient": patientResponse})
 }
 
+func (h *Handler) GetPatientHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditHistory, parsePatientID(idParam), fields) }()
+
+	versions, err := h.patientService.GetPatientHistory(idParam, role)
+	if err != nil {
+		if err.Error() == "patient archived" {
+			h.logger.Info("Patient is archived", zap.String("patientID", idParam))
+			c.JSON(http.StatusGone, gin.H{"error": "Patient is archived"})
+			return
+		}
+		var mergedErr *patient_service.PatientMergedError
+		if errors.As(err, &mergedErr) {
+			h.logger.Info("Patient was merged", zap.String("patientID", idParam), zap.Uint("targetID", mergedErr.TargetID))
+			c.Header("Location", fmt.Sprintf("/api/patient/%d/history", mergedErr.TargetID))
+			c.JSON(http.StatusMovedPermanently, gin.H{"merged_into": mergedErr.TargetID})
+			return
+		}
+		if err.Error() == "invalid patient ID" {
+			h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
+			c.JSON(400, gin.H{"error": "Invalid patient ID"})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to view patient history", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to view this patient"})
+			return
+		}
+		h.logger.Error("Failed to get patient history", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to get patient history"})
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, v := range versions {
+		for _, change := range v.Changes {
+			if !seen[change.Field] {
+				seen[change.Field] = true
+				fields = append(fields, change.Field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	c.JSON(200, gin.H{"history": versions})
+}
+
+func (h *Handler) ListPatients(c *gin.Context) {
+	role := c.GetString("role")
+
+	var fields []string
+	var patientIDs []uint
+	defer func() {
+		if len(patientIDs) == 0 {
+			h.auditPatientAccess(c, models.AuditList, nil, fields)
+			return
+		}
+		// one event per patient returned, so the log shows every listing a
+		// patient appeared in
+		for i := range patientIDs {
+			h.auditPatientAccess(c, models.AuditList, &patientIDs[i], fields)
+		}
+	}()
+
+	var query request.PatientQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind patient query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	for param := range c.Request.URL.Query() {
+		fields = append(fields, param)
+	}
+	sort.Strings(fields)
+
+	var patientList any
+	var err error
+	if query.Mode == "fuzzy" {
+		var searchResponse *response.PatientSearchResponse
+		searchResponse, err = h.patientService.SearchPatients(&query, role)
+		if err == nil {
+			for _, match := range searchResponse.Matches {
+				patientIDs = append(patientIDs, match.ID)
+			}
+		}
+		patientList = searchResponse
+	} else {
+		var listResponse *response.PatientListResponse
+		listResponse, err = h.patientService.ListPatients(&query, role)
+		if err == nil {
+			for _, patient := range listResponse.Patients {
+				patientIDs = append(patientIDs, patient.ID)
+			}
+		}
+		patientList = listResponse
+	}
+	if err != nil {
+		if err.Error() == "invalid date filter" || err.Error() == "name is required for fuzzy search" {
+			h.logger.Error("Invalid patient query", zap.Error(err))
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to list patients", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to view patients"})
+			return
+		}
+		h.logger.Error("Failed to list patients", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to list patients"})
+		return
+	}
+
+	c.JSON(200, patientList)
+}
+
 func (h *Handler) CreatePatient(c *gin.Context) {
 	var patientRequest request.PatientRequest
 	role := c.GetString("role")
 
+	var patientID *uint
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditCreate, patientID, fields) }()
+
 	if err := c.ShouldBindJSON(&patientRequest); err != nil {
 		h.logger.Error("Failed to bind patient request", zap.Error(err))
 		c.JSON(400, gin.H{"error": "Invalid request"})
 		return
 	}
+	fields = fieldNames(patientRequest)
 
-	patientResponse, err := h.patientService.CreatePatient(&patientRequest, role)
+	patientResponse, err := h.patientService.CreatePatient(&patientRequest, role, c.GetString("user_id"))
 	if err != nil {
+		var fieldErr *services.FieldAccessError
+		if errors.As(err, &fieldErr) {
+			h.logger.Warn("Field access denied", zap.String("role", role), zap.Strings("fields", fieldErr.Fields))
+			c.JSON(403, gin.H{"error": "You do not have permission to modify these fields", "fields": fieldErr.Fields})
+			return
+		}
+		var duplicateErr *patient_service.DuplicatePatientError
+		if errors.As(err, &duplicateErr) {
+			h.logger.Warn("Possible duplicate patient", zap.Int("candidates", len(duplicateErr.Candidates)))
+			c.JSON(http.StatusConflict, gin.H{
+				"error":      "Possible duplicate patient, set allow_duplicate to create anyway",
+				"candidates": duplicateErr.Candidates,
+			})
+			return
+		}
 		if err.Error() == "permission denied" {
 			h.logger.Warn("Permission denied to create patient", zap.String("role", role))
 			c.JSON(403, gin.H{"error": "You do not have permission to create a patient"})
 			return
 		}
+		if err.Error() == "email already in use" {
+			h.logger.Warn("Patient email conflict")
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to create patient", zap.Error(err))
 		c.JSON(500, gin.H{"error": "Failed to create patient"})
 		return
 	}
 
+	patientID = &patientResponse.ID
 	h.logger.Info("Patient created successfully", zap.String("patientID", fmt.Sprint(patientResponse.ID)))
 	c.JSON(201, gin.H{"patient": patientResponse})
 }
+
+func (h *Handler) MergePatient(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditMerge, parsePatientID(idParam), fields) }()
+
+	var mergeRequest request.PatientMergeRequest
+	if err := c.ShouldBindJSON(&mergeRequest); err != nil {
+		h.logger.Error("Failed to bind patient merge request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = []string{fmt.Sprintf("source_id:%d", mergeRequest.SourceID)}
+
+	patientResponse, err := h.patientService.MergePatient(idParam, &mergeRequest, role, c.GetString("user_id"))
+	if err != nil {
+		if err.Error() == "invalid patient ID" || err.Error() == "cannot merge a patient into itself" {
+			h.logger.Error("Invalid patient merge", zap.String("patientID", idParam), zap.Error(err))
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to merge patients", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to merge patients"})
+			return
+		}
+		if err.Error() == "patient not found" {
+			h.logger.Error("Patient not found for merge", zap.String("patientID", idParam), zap.Uint("sourceID", mergeRequest.SourceID))
+			c.JSON(404, gin.H{"error": "Patient not found"})
+			return
+		}
+		h.logger.Error("Failed to merge patients", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to merge patients"})
+		return
+	}
+
+	h.logger.Info("Patients merged successfully", zap.String("patientID", idParam), zap.Uint("sourceID", mergeRequest.SourceID))
+	c.JSON(200, gin.H{"patient": patientResponse})
+}
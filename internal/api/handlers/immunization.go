@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) RecordImmunization(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "immunization", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var immunizationRequest request.ImmunizationRequest
+	if err := c.ShouldBindJSON(&immunizationRequest); err != nil {
+		h.logger.Error("Failed to bind immunization request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(immunizationRequest)
+
+	immunization, err := h.immunizationService.RecordImmunization(idParam, &immunizationRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondImmunizationError(c, err, "record", idParam)
+		return
+	}
+
+	h.logger.Info("Immunization recorded successfully", zap.String("patientID", idParam), zap.Uint("immunizationID", immunization.ID))
+	c.JSON(http.StatusCreated, gin.H{"immunization": immunization})
+}
+
+func (h *Handler) ListImmunizations(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "immunization", models.AuditList, parsePatientID(idParam), nil) }()
+
+	immunizations, err := h.immunizationService.ListImmunizations(idParam, role)
+	if err != nil {
+		h.respondImmunizationError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"immunizations": immunizations})
+}
+
+func (h *Handler) GetImmunizationForecast(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "immunization_forecast", models.AuditView, parsePatientID(idParam), nil) }()
+
+	var query request.ImmunizationForecastQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind immunization forecast query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	forecast, err := h.immunizationService.GetForecast(idParam, &query, role)
+	if err != nil {
+		h.respondImmunizationError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"forecast": forecast})
+}
+
+func (h *Handler) respondImmunizationError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid administered_on", "invalid as_of",
+		"administered_on is in the future", "administered_on is before date of birth":
+		h.logger.Error("Invalid immunization request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" immunizations", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " immunizations"})
+	case "patient not found":
+		h.logger.Error("Patient not found", zap.String("patientID", idParam))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" immunizations", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " immunizations"})
+	}
+}
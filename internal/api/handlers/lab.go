@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CreateLabOrder(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "lab_order", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var orderRequest request.LabOrderRequest
+	if err := c.ShouldBindJSON(&orderRequest); err != nil {
+		h.logger.Error("Failed to bind lab order request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(orderRequest)
+
+	order, err := h.labService.CreateLabOrder(idParam, &orderRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondLabError(c, err, "create", idParam)
+		return
+	}
+
+	h.logger.Info("Lab order created successfully", zap.String("patientID", idParam), zap.Uint("labOrderID", order.ID))
+	c.JSON(http.StatusCreated, gin.H{"lab_order": order})
+}
+
+func (h *Handler) ListLabOrders(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "lab_order", models.AuditList, parsePatientID(idParam), nil) }()
+
+	var query request.LabOrderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind lab order query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	orders, err := h.labService.ListLabOrders(idParam, &query, role)
+	if err != nil {
+		h.respondLabError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"lab_orders": orders})
+}
+
+func (h *Handler) GetLabOrderById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "lab_order", models.AuditView, parsePatientID(idParam), nil) }()
+
+	order, err := h.labService.GetLabOrderById(idParam, c.Param("orderId"), role)
+	if err != nil {
+		h.respondLabError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"lab_order": order})
+}
+
+func (h *Handler) CollectLabOrder(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "lab_order", models.AuditCollect, parsePatientID(idParam), nil) }()
+
+	order, err := h.labService.CollectLabOrder(idParam, c.Param("orderId"), role, c.GetString("user_id"))
+	if err != nil {
+		h.respondLabError(c, err, "collect", idParam)
+		return
+	}
+
+	h.logger.Info("Lab specimen collected", zap.String("patientID", idParam), zap.Uint("labOrderID", order.ID))
+	c.JSON(200, gin.H{"lab_order": order})
+}
+
+func (h *Handler) CancelLabOrder(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "lab_order", models.AuditCancel, parsePatientID(idParam), nil) }()
+
+	var cancelRequest request.CancelLabOrderRequest
+	if err := c.ShouldBindJSON(&cancelRequest); err != nil {
+		h.logger.Error("Failed to bind cancel request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	order, err := h.labService.CancelLabOrder(idParam, c.Param("orderId"), &cancelRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondLabError(c, err, "cancel", idParam)
+		return
+	}
+
+	h.logger.Info("Lab order cancelled successfully", zap.String("patientID", idParam), zap.Uint("labOrderID", order.ID))
+	c.JSON(200, gin.H{"lab_order": order})
+}
+
+func (h *Handler) RecordLabResults(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "lab_order", models.AuditResult, parsePatientID(idParam), fields) }()
+
+	var resultsRequest request.LabResultsRequest
+	if err := c.ShouldBindJSON(&resultsRequest); err != nil {
+		h.logger.Error("Failed to bind lab results request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(resultsRequest)
+
+	order, err := h.labService.RecordLabResults(idParam, c.Param("orderId"), &resultsRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondLabError(c, err, "record results for", idParam)
+		return
+	}
+
+	h.logger.Info("Lab results recorded", zap.String("patientID", idParam), zap.Uint("labOrderID", order.ID))
+	c.JSON(200, gin.H{"lab_order": order})
+}
+
+func (h *Handler) respondLabError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid lab order ID", "result needs a value", "invalid reference range", "invalid critical range":
+		h.logger.Error("Invalid lab order request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" lab order", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this lab order"})
+	case "patient not found", "encounter not found", "lab order not found":
+		h.logger.Error("Lab order lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "invalid status transition":
+		h.logger.Warn("Invalid lab order status transition", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(409, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" lab order", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " lab order"})
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CreateNote(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	var noteID *uint
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditCreate, parsePatientID(idParam), noteID, nil, fields)
+	}()
+
+	var noteRequest request.NoteRequest
+	if err := c.ShouldBindJSON(&noteRequest); err != nil {
+		h.logger.Error("Failed to bind note request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(noteRequest)
+
+	note, err := h.noteService.CreateNote(idParam, c.Param("encounterId"), &noteRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondNoteError(c, err, "create", idParam)
+		return
+	}
+	noteID = &note.ID
+
+	h.logger.Info("Note created successfully", zap.String("patientID", idParam), zap.Uint("noteID", note.ID))
+	c.JSON(http.StatusCreated, gin.H{"note": note})
+}
+
+func (h *Handler) ListEncounterNotes(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "note", models.AuditList, parsePatientID(idParam), nil) }()
+
+	notes, err := h.noteService.ListEncounterNotes(idParam, c.Param("encounterId"), role)
+	if err != nil {
+		h.respondNoteError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"notes": notes})
+}
+
+func (h *Handler) GetNoteById(c *gin.Context) {
+	idParam := c.Param("id")
+	noteParam := c.Param("noteId")
+	role := c.GetString("role")
+
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditView, parsePatientID(idParam), parseResourceID(noteParam), nil, nil)
+	}()
+
+	note, err := h.noteService.GetNoteById(idParam, noteParam, role)
+	if err != nil {
+		h.respondNoteError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"note": note})
+}
+
+func (h *Handler) UpdateNoteById(c *gin.Context) {
+	idParam := c.Param("id")
+	noteParam := c.Param("noteId")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditUpdate, parsePatientID(idParam), parseResourceID(noteParam), nil, fields)
+	}()
+
+	var updateRequest request.NoteUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
+		h.logger.Error("Failed to bind note update request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(updateRequest)
+
+	note, err := h.noteService.UpdateNoteById(idParam, noteParam, &updateRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondNoteError(c, err, "update", idParam)
+		return
+	}
+
+	h.logger.Info("Note updated successfully", zap.String("patientID", idParam), zap.Uint("noteID", note.ID))
+	c.JSON(200, gin.H{"note": note})
+}
+
+func (h *Handler) SignNote(c *gin.Context) {
+	idParam := c.Param("id")
+	noteParam := c.Param("noteId")
+	role := c.GetString("role")
+
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditSign, parsePatientID(idParam), parseResourceID(noteParam), nil, nil)
+	}()
+
+	note, err := h.noteService.SignNote(idParam, noteParam, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondNoteError(c, err, "sign", idParam)
+		return
+	}
+
+	h.logger.Info("Note signed successfully", zap.String("patientID", idParam), zap.Uint("noteID", note.ID))
+	c.JSON(200, gin.H{"note": note})
+}
+
+func (h *Handler) AddNoteAddendum(c *gin.Context) {
+	idParam := c.Param("id")
+	noteParam := c.Param("noteId")
+	role := c.GetString("role")
+
+	var fields []string
+	var addendumID *uint
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditAddendum, parsePatientID(idParam), addendumID, parseResourceID(noteParam), fields)
+	}()
+
+	var noteRequest request.NoteRequest
+	if err := c.ShouldBindJSON(&noteRequest); err != nil {
+		h.logger.Error("Failed to bind addendum request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(noteRequest)
+
+	addendum, err := h.noteService.AddAddendum(idParam, noteParam, &noteRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondNoteError(c, err, "amend", idParam)
+		return
+	}
+	addendumID = &addendum.ID
+
+	h.logger.Info("Addendum created successfully", zap.String("patientID", idParam), zap.Uint("noteID", addendum.ID))
+	c.JSON(http.StatusCreated, gin.H{"note": addendum})
+}
+
+func (h *Handler) GetNoteHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	noteParam := c.Param("noteId")
+	role := c.GetString("role")
+
+	defer func() {
+		h.auditRecordAccess(c, "note", models.AuditHistory, parsePatientID(idParam), parseResourceID(noteParam), nil, nil)
+	}()
+
+	history, err := h.noteService.GetNoteHistory(idParam, noteParam, role)
+	if err != nil {
+		h.respondNoteError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"history": history})
+}
+
+func (h *Handler) respondNoteError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid encounter ID", "invalid note ID", "note is empty", "no fields to update",
+		"addenda must reference the original note":
+		h.logger.Error("Invalid note request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" note", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this note"})
+	case "patient not found", "encounter not found", "note not found":
+		h.logger.Error("Note lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "note is signed", "only signed notes take addenda":
+		h.logger.Warn("Note conflict", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(409, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" note", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " note"})
+	}
+}
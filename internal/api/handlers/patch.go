@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/patch"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) PatchUserById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	p, ok := h.bindPatch(c)
+	if !ok {
+		return
+	}
+
+	userResponse, err := h.userService.PatchUserById(idParam, p, role, c.GetString("user_id"))
+	if err != nil {
+		if h.respondPatchError(c, err) {
+			return
+		}
+		if err.Error() == "invalid user ID" {
+			h.logger.Error("Invalid user ID", zap.String("userID", idParam))
+			c.JSON(400, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		if err.Error() == "patched user is invalid" {
+			h.logger.Error("Patch produced an invalid user", zap.String("userID", idParam))
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to update user", zap.String("userID", idParam))
+			c.JSON(403, gin.H{"error": "You do not have permission to update this user"})
+			return
+		}
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		if err.Error() == "user already exists" {
+			h.logger.Error("User already exists", zap.String("userID", idParam))
+			c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
+			return
+		}
+		h.logger.Error("Failed to patch user", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to update user"})
+		return
+	}
+
+	h.logger.Info("User patched successfully", zap.String("userID", idParam))
+	c.JSON(200, gin.H{"user": userResponse})
+}
+
+func (h *Handler) PatchPatientById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditUpdate, parsePatientID(idParam), fields) }()
+
+	ifMatch, ok := h.requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	p, ok := h.bindPatch(c)
+	if !ok {
+		return
+	}
+	fields = p.Fields()
+
+	patientResponse, err := h.patientService.PatchPatientById(idParam, p, ifMatch, role, c.GetString("user_id"))
+	if err != nil {
+		if h.respondPatchError(c, err) || h.respondPreconditionFailed(c, err) {
+			return
+		}
+		var fieldErr *services.FieldAccessError
+		if errors.As(err, &fieldErr) {
+			h.logger.Warn("Field access denied", zap.String("role", role), zap.Strings("fields", fieldErr.Fields))
+			c.JSON(403, gin.H{"error": "You do not have permission to modify these fields", "fields": fieldErr.Fields})
+			return
+		}
+		if err.Error() == "invalid patient ID" {
+			h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
+			c.JSON(400, gin.H{"error": "Invalid patient ID"})
+			return
+		}
+		if err.Error() == "patched patient is invalid" {
+			h.logger.Error("Patch produced an invalid patient", zap.String("patientID", idParam))
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to update patient", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to update this patient"})
+			return
+		}
+		if err.Error() == "patient not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Patient not found"})
+			return
+		}
+		if err.Error() == "email already in use" {
+			h.logger.Warn("Patient email conflict", zap.String("patientID", idParam))
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to patch patient", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to update patient"})
+		return
+	}
+
+	h.logger.Info("Patient patched successfully", zap.String("patientID", idParam))
+	c.Header("ETag", patientETag(patientResponse.Version))
+	c.JSON(200, gin.H{"patient": patientResponse})
+}
+
+// bindPatch parses the request body as a JSON Patch or JSON Merge Patch
+// depending on its Content-Type, writing the error response on failure.
+func (h *Handler) bindPatch(c *gin.Context) (patch.Patch, bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		h.logger.Error("Failed to read patch body", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return nil, false
+	}
+
+	p, err := patch.Parse(c.ContentType(), body)
+	if err != nil {
+		if errors.Is(err, patch.ErrUnsupportedMediaType) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{
+				"error":     "Content-Type must be " + patch.MergePatchMediaType + " or " + patch.JSONPatchMediaType,
+				"supported": []string{patch.MergePatchMediaType, patch.JSONPatchMediaType},
+			})
+			return nil, false
+		}
+		h.logger.Error("Failed to parse patch", zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return p, true
+}
+
+// respondPatchError writes the response for errors raised while applying a
+// patch and reports whether err was one of them.
+func (h *Handler) respondPatchError(c *gin.Context, err error) bool {
+	var testErr *patch.TestFailedError
+	if errors.As(err, &testErr) {
+		h.logger.Info("Patch test failed", zap.String("path", testErr.Path))
+		c.JSON(http.StatusConflict, gin.H{"error": "Patch test failed", "path": testErr.Path})
+		return true
+	}
+	var applyErr *patch.ApplyError
+	if errors.As(err, &applyErr) {
+		h.logger.Error("Failed to apply patch", zap.Error(err))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": applyErr.Error()})
+		return true
+	}
+	return false
+}
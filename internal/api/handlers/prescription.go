@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/prescription_service"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) CreatePrescription(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "prescription", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var prescriptionRequest request.PrescriptionRequest
+	if err := c.ShouldBindJSON(&prescriptionRequest); err != nil {
+		h.logger.Error("Failed to bind prescription request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(prescriptionRequest)
+
+	prescription, err := h.prescriptionService.CreatePrescription(idParam, &prescriptionRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondPrescriptionError(c, err, "record", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription recorded successfully", zap.String("patientID", idParam), zap.Uint("prescriptionID", prescription.ID))
+	c.JSON(http.StatusCreated, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) ListPrescriptions(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditList, parsePatientID(idParam), nil) }()
+
+	var query request.PrescriptionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind prescription query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	prescriptions, err := h.prescriptionService.ListPrescriptions(idParam, &query, role)
+	if err != nil {
+		h.respondPrescriptionError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"prescriptions": prescriptions})
+}
+
+func (h *Handler) GetPrescriptionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditView, parsePatientID(idParam), nil) }()
+
+	prescription, err := h.prescriptionService.GetPrescriptionById(idParam, c.Param("prescriptionId"), role)
+	if err != nil {
+		h.respondPrescriptionError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) UpdatePrescriptionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "prescription", models.AuditUpdate, parsePatientID(idParam), fields) }()
+
+	var updateRequest request.PrescriptionUpdateRequest
+	if err := bindStrictJSON(c, &updateRequest); err != nil {
+		h.logger.Error("Failed to bind prescription update request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(updateRequest)
+
+	prescription, err := h.prescriptionService.UpdatePrescriptionById(idParam, c.Param("prescriptionId"), &updateRequest, role)
+	if err != nil {
+		h.respondPrescriptionError(c, err, "update", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription updated successfully", zap.String("patientID", idParam), zap.Uint("prescriptionID", prescription.ID))
+	c.JSON(200, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) DeletePrescriptionById(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditDelete, parsePatientID(idParam), nil) }()
+
+	if err := h.prescriptionService.DeletePrescriptionById(idParam, c.Param("prescriptionId"), role); err != nil {
+		h.respondPrescriptionError(c, err, "delete", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription deleted successfully", zap.String("patientID", idParam), zap.String("prescriptionID", c.Param("prescriptionId")))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) SignPrescription(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditSign, parsePatientID(idParam), nil) }()
+
+	prescription, err := h.prescriptionService.SignPrescription(idParam, c.Param("prescriptionId"), role, c.GetString("user_id"))
+	if err != nil {
+		h.respondPrescriptionError(c, err, "sign", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription signed successfully", zap.String("patientID", idParam), zap.Uint("prescriptionID", prescription.ID))
+	c.JSON(200, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) DispensePrescription(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditDispense, parsePatientID(idParam), nil) }()
+
+	prescription, err := h.prescriptionService.DispensePrescription(idParam, c.Param("prescriptionId"), role, c.GetString("user_id"))
+	if err != nil {
+		h.respondPrescriptionError(c, err, "dispense", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription dispensed successfully", zap.String("patientID", idParam), zap.Uint("prescriptionID", prescription.ID))
+	c.JSON(200, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) DiscontinuePrescription(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "prescription", models.AuditDiscontinue, parsePatientID(idParam), nil) }()
+
+	var discontinueRequest request.DiscontinuePrescriptionRequest
+	if err := c.ShouldBindJSON(&discontinueRequest); err != nil {
+		h.logger.Error("Failed to bind discontinue request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	prescription, err := h.prescriptionService.DiscontinuePrescription(idParam, c.Param("prescriptionId"), &discontinueRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondPrescriptionError(c, err, "discontinue", idParam)
+		return
+	}
+
+	h.logger.Info("Prescription discontinued successfully", zap.String("patientID", idParam), zap.Uint("prescriptionID", prescription.ID))
+	c.JSON(200, gin.H{"prescription": prescription})
+}
+
+func (h *Handler) respondPrescriptionError(c *gin.Context, err error, action, idParam string) {
+	var conflictErr *prescription_service.InteractionConflictError
+	if errors.As(err, &conflictErr) {
+		h.logger.Warn("Prescription has unresolved interactions", zap.String("patientID", idParam), zap.Int("warnings", len(conflictErr.Warnings)))
+		c.JSON(http.StatusConflict, gin.H{
+			"error":    "High-severity interaction found, set override_reason to prescribe anyway",
+			"warnings": conflictErr.Warnings,
+		})
+		return
+	}
+
+	switch err.Error() {
+	case "invalid patient ID", "invalid prescription ID", "no fields to update":
+		h.logger.Error("Invalid prescription request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" prescription", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this prescription"})
+	case "patient not found", "prescription not found", "encounter not found":
+		h.logger.Error("Prescription lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	case "prescription is locked", "invalid status transition":
+		h.logger.Warn("Prescription conflict", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" prescription", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " prescription"})
+	}
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) ListRetentionRuns(c *gin.Context) {
+	role := c.GetString("role")
+
+	runs, err := h.retentionService.ListRuns(role)
+	if err != nil {
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to view retention runs", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to manage data retention"})
+			return
+		}
+		h.logger.Error("Failed to list retention runs", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to list retention runs"})
+		return
+	}
+
+	c.JSON(200, gin.H{"runs": runs})
+}
+
+func (h *Handler) DryRunRetention(c *gin.Context) {
+	role := c.GetString("role")
+
+	run, err := h.retentionService.DryRun(role, c.GetString("user_id"))
+	if err != nil {
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to dry-run retention", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to manage data retention"})
+			return
+		}
+		h.logger.Error("Failed to dry-run retention", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to dry-run retention"})
+		return
+	}
+
+	h.logger.Info("Retention dry run completed", zap.Uint("runID", run.ID))
+	c.JSON(200, gin.H{"run": run})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) GetPatientSummary(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditPatientAccess(c, models.AuditView, parsePatientID(idParam), fields) }()
+
+	summary, err := h.summaryService.GetPatientSummary(idParam, role)
+	if err != nil {
+		if err.Error() == "invalid patient ID" {
+			h.logger.Error("Invalid patient ID", zap.String("patientID", idParam))
+			c.JSON(400, gin.H{"error": "Invalid patient ID"})
+			return
+		}
+		if err.Error() == "permission denied" {
+			h.logger.Warn("Permission denied to view patient summary", zap.String("role", role))
+			c.JSON(403, gin.H{"error": "You do not have permission to view this patient"})
+			return
+		}
+		if err.Error() == "patient not found" {
+			c.JSON(404, gin.H{"error": "Patient not found"})
+			return
+		}
+		h.logger.Error("Failed to get patient summary", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to get patient summary"})
+		return
+	}
+
+	fields = fieldNames(summary.Patient)
+	if summary.Allergies != nil {
+		fields = append(fields, "allergies")
+	}
+	if summary.ActiveConditions != nil {
+		fields = append(fields, "active_conditions")
+	}
+	if summary.Medications != nil {
+		fields = append(fields, "medications")
+	}
+	c.JSON(200, gin.H{"summary": summary})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) RecordVital(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "vitals", models.AuditCreate, parsePatientID(idParam), fields) }()
+
+	var vitalRequest request.VitalRequest
+	if err := c.ShouldBindJSON(&vitalRequest); err != nil {
+		h.logger.Error("Failed to bind vital request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(vitalRequest)
+
+	vital, err := h.vitalService.RecordVital(idParam, &vitalRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondVitalError(c, err, "record", idParam)
+		return
+	}
+
+	h.logger.Info("Vitals recorded successfully", zap.String("patientID", idParam), zap.Uint("vitalID", vital.ID))
+	c.JSON(http.StatusCreated, gin.H{"vital": vital})
+}
+
+func (h *Handler) ListVitals(c *gin.Context) {
+	idParam := c.Param("id")
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "vitals", models.AuditList, parsePatientID(idParam), nil) }()
+
+	var query request.VitalQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind vitals query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	vitals, err := h.vitalService.ListVitals(idParam, &query, role)
+	if err != nil {
+		h.respondVitalError(c, err, "view", idParam)
+		return
+	}
+
+	c.JSON(200, gin.H{"vitals": vitals})
+}
+
+func (h *Handler) respondVitalError(c *gin.Context, err error, action, idParam string) {
+	switch err.Error() {
+	case "invalid patient ID", "invalid recorded_at", "invalid time range", "no measurements recorded",
+		"blood pressure needs systolic and diastolic", "diastolic must be below systolic", "recorded_at is in the future":
+		h.logger.Error("Invalid vitals request", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" vitals", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " vitals"})
+	case "patient not found", "encounter not found":
+		h.logger.Error("Vitals lookup failed", zap.String("patientID", idParam), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" vitals", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " vitals"})
+	}
+}
+
+func (h *Handler) ListVitalRules(c *gin.Context) {
+	role := c.GetString("role")
+
+	var query request.VitalRuleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind vital rule query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	rules, err := h.vitalService.ListVitalRules(&query, role)
+	if err != nil {
+		h.respondVitalRuleError(c, err, "view")
+		return
+	}
+
+	c.JSON(200, gin.H{"rules": rules})
+}
+
+func (h *Handler) CreateVitalRule(c *gin.Context) {
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "vital_rule", models.AuditCreate, nil, fields) }()
+
+	var ruleRequest request.VitalRuleRequest
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		h.logger.Error("Failed to bind vital rule request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(ruleRequest)
+
+	rule, err := h.vitalService.CreateVitalRule(&ruleRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondVitalRuleError(c, err, "create")
+		return
+	}
+
+	h.logger.Info("Vital rule created successfully", zap.Uint("ruleID", rule.ID))
+	c.JSON(http.StatusCreated, gin.H{"rule": rule})
+}
+
+func (h *Handler) UpdateVitalRuleById(c *gin.Context) {
+	role := c.GetString("role")
+
+	var fields []string
+	defer func() { h.auditAccess(c, "vital_rule", models.AuditUpdate, nil, fields) }()
+
+	var ruleRequest request.VitalRuleRequest
+	if err := bindStrictJSON(c, &ruleRequest); err != nil {
+		h.logger.Error("Failed to bind vital rule request", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	fields = fieldNames(ruleRequest)
+
+	rule, err := h.vitalService.UpdateVitalRuleById(c.Param("ruleId"), &ruleRequest, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondVitalRuleError(c, err, "update")
+		return
+	}
+
+	h.logger.Info("Vital rule updated successfully", zap.Uint("ruleID", rule.ID))
+	c.JSON(200, gin.H{"rule": rule})
+}
+
+func (h *Handler) DeleteVitalRuleById(c *gin.Context) {
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "vital_rule", models.AuditDelete, nil, nil) }()
+
+	if err := h.vitalService.DeleteVitalRuleById(c.Param("ruleId"), role); err != nil {
+		h.respondVitalRuleError(c, err, "delete")
+		return
+	}
+
+	h.logger.Info("Vital rule deleted successfully", zap.String("ruleID", c.Param("ruleId")))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondVitalRuleError(c *gin.Context, err error, action string) {
+	switch err.Error() {
+	case "invalid vital rule ID", "rule needs a low or high threshold", "low must be below high", "max_age_years must be above min_age_years":
+		h.logger.Error("Invalid vital rule request", zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" vital rule", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " vital rules"})
+	case "vital rule not found":
+		h.logger.Error("Vital rule lookup failed", zap.String("ruleID", c.Param("ruleId")), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" vital rule", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " vital rule"})
+	}
+}
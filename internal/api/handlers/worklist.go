@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) ListWorklist(c *gin.Context) {
+	role := c.GetString("role")
+
+	defer func() { h.auditAccess(c, "worklist", models.AuditList, nil, nil) }()
+
+	var query request.WorklistQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Failed to bind worklist query", zap.Error(err))
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	items, err := h.worklistService.ListWorklist(c.Param("id"), &query, role, c.GetString("user_id"))
+	if err != nil {
+		h.respondWorklistError(c, err, "view")
+		return
+	}
+
+	c.JSON(200, gin.H{"items": items})
+}
+
+func (h *Handler) AcknowledgeWorklistItem(c *gin.Context) {
+	role := c.GetString("role")
+
+	var patientID *uint
+	defer func() { h.auditAccess(c, "worklist", models.AuditAcknowledge, patientID, nil) }()
+
+	item, err := h.worklistService.AcknowledgeWorklistItem(c.Param("id"), c.Param("itemId"), role, c.GetString("user_id"))
+	if err != nil {
+		h.respondWorklistError(c, err, "acknowledge")
+		return
+	}
+	patientID = &item.PatientID
+
+	h.logger.Info("Worklist item acknowledged", zap.String("doctorID", c.Param("id")), zap.Uint("itemID", item.ID))
+	c.JSON(200, gin.H{"item": item})
+}
+
+func (h *Handler) respondWorklistError(c *gin.Context, err error, action string) {
+	switch err.Error() {
+	case "invalid doctor ID", "invalid worklist item ID":
+		h.logger.Error("Invalid worklist request", zap.Error(err))
+		c.JSON(400, gin.H{"error": err.Error()})
+	case "permission denied":
+		h.logger.Warn("Permission denied to "+action+" worklist", zap.String("role", c.GetString("role")))
+		c.JSON(403, gin.H{"error": "You do not have permission to " + action + " this worklist"})
+	case "worklist item not found":
+		h.logger.Error("Worklist lookup failed", zap.String("doctorID", c.Param("id")), zap.Error(err))
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to "+action+" worklist", zap.Error(err))
+		c.JSON(500, gin.H{"error": "Failed to " + action + " worklist"})
+	}
+}
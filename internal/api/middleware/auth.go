@@ -48,6 +48,7 @@ func RoleMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set("role", claims.Role)
+		c.Set("user_id", claims.Subject)
 		c.Next()
 	}
 }
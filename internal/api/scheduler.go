@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/services/retention_service"
+	"go.uber.org/zap"
+)
+
+// startRetentionScheduler applies the retention rules every interval until
+// ctx is cancelled. A non-positive interval disables it.
+func startRetentionScheduler(ctx context.Context, logger *zap.Logger, retentionService *retention_service.RetentionService, interval time.Duration) {
+	if interval <= 0 {
+		logger.Info("Retention scheduler disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run, err := retentionService.Run(false, retention_service.SchedulerActor)
+				if err != nil {
+					logger.Error("Failed to store retention run", zap.Error(err))
+					continue
+				}
+				for _, result := range run.Results {
+					if result.Error != "" {
+						logger.Error("Retention rule failed", zap.Uint("runID", run.ID), zap.String("rule", result.Rule),
+							zap.Int64("affected", result.Affected), zap.String("error", result.Error))
+						continue
+					}
+					logger.Info("Retention rule applied", zap.Uint("runID", run.ID), zap.String("rule", result.Rule),
+						zap.Int64("affected", result.Affected))
+				}
+			}
+		}
+	}()
+}
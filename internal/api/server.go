@@ -1,14 +1,31 @@
 package api
 
 import (
+	"context"
 	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
 	"github.com/palashbhasme/healthcare-portal/internal/api/handlers"
 	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services/allergy_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/appointment_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/audit_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/availability_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/code_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/condition_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/encounter_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/immunization_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/lab_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/note_service"
 	"github.com/palashbhasme/healthcare-portal/internal/services/patient_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/prescription_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/retention_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/summary_service"
 	"github.com/palashbhasme/healthcare-portal/internal/services/user_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/vital_service"
+	"github.com/palashbhasme/healthcare-portal/internal/services/worklist_service"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -16,14 +33,88 @@ import (
 func Server(logger *zap.Logger, db *gorm.DB) error {
 	router := gin.Default()
 
+	if err := request.RegisterValidators(); err != nil {
+		return err
+	}
+
 	userRepo := repository.NewUserRepository(db)
 	patientRepo := repository.NewPatientRepository(db)
+	appointmentRepo := repository.NewAppointmentRepository(db)
+	availabilityRepo := repository.NewAvailabilityRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	conditionRepo := repository.NewConditionRepository(db)
+	codeRepo := repository.NewCodeRepository(db)
+	allergyRepo := repository.NewAllergyRepository(db)
+	prescriptionRepo := repository.NewPrescriptionRepository(db)
+	vitalRepo := repository.NewVitalRepository(db)
+	vitalRuleRepo := repository.NewVitalRuleRepository(db)
+	worklistRepo := repository.NewWorklistRepository(db)
+	encounterRepo := repository.NewEncounterRepository(db)
+	noteRepo := repository.NewNoteRepository(db)
+	labRepo := repository.NewLabRepository(db)
+	immunizationRepo := repository.NewImmunizationRepository(db)
+	retentionConfig := config.LoadRetentionConfig()
+	clinicConfig, err := config.LoadClinicConfig()
+	if err != nil {
+		return err
+	}
 
 	userService := user_service.NewUserService(userRepo)
-	patientService := patient_service.NewPatientService(patientRepo)
+	patientService := patient_service.NewPatientService(patientRepo, retentionConfig)
+	appointmentService := appointment_service.NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, clinicConfig)
+	availabilityService := availability_service.NewAvailabilityService(availabilityRepo, appointmentRepo, userRepo, clinicConfig)
+	auditService := audit_service.NewAuditService(auditRepo)
+	retentionService := retention_service.NewRetentionService(patientRepo, auditRepo, retentionRepo, retentionConfig)
+	conditionService := condition_service.NewConditionService(conditionRepo, patientRepo, codeRepo, encounterRepo)
+	codeService := code_service.NewCodeService(codeRepo)
+	allergyService := allergy_service.NewAllergyService(allergyRepo, patientRepo)
+	summaryService := summary_service.NewSummaryService(patientRepo, allergyRepo, conditionRepo, prescriptionRepo)
+	interactions, err := prescription_service.LoadInteractionTable(os.Getenv("DRUG_INTERACTIONS_PATH"))
+	if err != nil {
+		return err
+	}
+	prescriptionService := prescription_service.NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, encounterRepo, interactions)
+	vitalService := vital_service.NewVitalService(vitalRepo, vitalRuleRepo, patientRepo, appointmentRepo, encounterRepo)
+	worklistService := worklist_service.NewWorklistService(worklistRepo)
+	encounterService := encounter_service.NewEncounterService(encounterRepo, patientRepo, appointmentRepo, userRepo, vitalRepo, conditionRepo, prescriptionRepo, noteRepo, labRepo)
+	noteService := note_service.NewNoteService(noteRepo, patientRepo, encounterRepo)
+	labService := lab_service.NewLabService(labRepo, patientRepo, encounterRepo)
+	schedule, err := immunization_service.LoadImmunizationSchedule(os.Getenv("IMMUNIZATION_SCHEDULE_PATH"))
+	if err != nil {
+		return err
+	}
+	immunizationService := immunization_service.NewImmunizationService(immunizationRepo, patientRepo, schedule)
 	authConfig := config.NewAuthConfig(os.Getenv("JWT_SECRET"))
 
-	handlers.NewHandler(router, logger, userService, patientService, authConfig)
+	handlers.NewHandler(router, logger, userService, patientService, appointmentService, availabilityService, auditService, retentionService, conditionService, codeService, allergyService, summaryService, prescriptionService, vitalService, worklistService, encounterService, noteService, labService, immunizationService, authConfig)
+
+	loaded, err := codeService.LoadBundledICD10()
+	if err != nil {
+		return err
+	}
+	if loaded > 0 {
+		logger.Info("Loaded bundled ICD-10 catalog", zap.Int("codes", loaded))
+	}
+	imported, err := codeService.ICD10CatalogImported()
+	if err != nil {
+		return err
+	}
+	if !imported {
+		logger.Warn("Only the bundled ICD-10 subset is loaded; condition codes missing from it are checked for format only. Run import-icd10 with the full CMS table to enforce the catalog")
+	}
+
+	loaded, err = vitalService.LoadBundledVitalRules()
+	if err != nil {
+		return err
+	}
+	if loaded > 0 {
+		logger.Info("Loaded default vital threshold rules", zap.Int("rules", loaded))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startRetentionScheduler(ctx, logger, retentionService, retentionConfig.Interval)
 
 	if err := router.Run(":8080"); err != nil {
 		return err
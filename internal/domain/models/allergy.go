@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AllergySeverity string
+
+const (
+	AllergyMild     AllergySeverity = "mild"
+	AllergyModerate AllergySeverity = "moderate"
+	AllergySevere   AllergySeverity = "severe"
+)
+
+// AllergyVerificationStatus follows the FHIR AllergyIntolerance values.
+// Refuted and entered-in-error records are kept for the record but are not
+// clinically relevant.
+type AllergyVerificationStatus string
+
+const (
+	AllergyUnconfirmed    AllergyVerificationStatus = "unconfirmed"
+	AllergyConfirmed      AllergyVerificationStatus = "confirmed"
+	AllergyRefuted        AllergyVerificationStatus = "refuted"
+	AllergyEnteredInError AllergyVerificationStatus = "entered-in-error"
+)
+
+// Allergy is an allergy or intolerance recorded for a patient.
+type Allergy struct {
+	ID                 uint                      `gorm:"primaryKey"`
+	PatientID          uint                      `gorm:"not null;index"`
+	Substance          string                    `gorm:"type:varchar(255);not null"`
+	Reaction           string                    `gorm:"type:text"`
+	Severity           AllergySeverity           `gorm:"type:varchar(20);not null"`
+	VerificationStatus AllergyVerificationStatus `gorm:"type:varchar(20);not null"`
+	RecordedBy         string                    `gorm:"type:varchar(64);not null"`
+	CreatedAt          time.Time                 `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time                 `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt            `gorm:"index"`
+}
+
+// IsRelevant reports whether the allergy should be considered when treating
+// the patient.
+func (a *Allergy) IsRelevant() bool {
+	return a.VerificationStatus != AllergyRefuted && a.VerificationStatus != AllergyEnteredInError
+}
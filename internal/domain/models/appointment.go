@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type AppointmentStatus string
+
+const (
+	AppointmentScheduled AppointmentStatus = "scheduled"
+	AppointmentCancelled AppointmentStatus = "cancelled"
+)
+
+// AppointmentOverlapConstraint is the exclusion constraint that stops a
+// doctor having two scheduled appointments whose [start, end) ranges
+// intersect.
+const AppointmentOverlapConstraint = "appointments_no_overlap"
+
+type Appointment struct {
+	ID        uint              `gorm:"primaryKey"`
+	PatientID uint              `gorm:"not null;index"`
+	DoctorID  uint              `gorm:"not null;index"`
+	StartTime time.Time         `gorm:"not null;index"`
+	EndTime   time.Time         `gorm:"not null"`
+	Reason    string            `gorm:"type:text"`
+	Status    AppointmentStatus `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditView        AuditAction = "view"
+	AuditList        AuditAction = "list"
+	AuditCreate      AuditAction = "create"
+	AuditUpdate      AuditAction = "update"
+	AuditMerge       AuditAction = "merge"
+	AuditHistory     AuditAction = "history"
+	AuditArchive     AuditAction = "archive"
+	AuditRestore     AuditAction = "restore"
+	AuditPurge       AuditAction = "purge"
+	AuditDelete      AuditAction = "delete"
+	AuditSign        AuditAction = "sign"
+	AuditDispense    AuditAction = "dispense"
+	AuditDiscontinue AuditAction = "discontinue"
+	AuditAcknowledge AuditAction = "acknowledge"
+	AuditCheckIn     AuditAction = "check_in"
+	AuditCheckOut    AuditAction = "check_out"
+	AuditAddendum    AuditAction = "addendum"
+	AuditCollect     AuditAction = "collect"
+	AuditResult      AuditAction = "result"
+	AuditCancel      AuditAction = "cancel"
+)
+
+// AuditEvent is a single access to protected health information. Rows are
+// append-only; a database trigger rejects updates and deletes, and each row
+// carries the hash of its predecessor so edits made around the trigger can be
+// detected. ResourceID names the record accessed when the resource is not the
+// patient itself, and ParentResourceID the record it amends, such as the note
+// an addendum is attached to.
+type AuditEvent struct {
+	ID               uint   `gorm:"primaryKey"`
+	ActorID          string `gorm:"type:varchar(64);not null;index"`
+	Role             string `gorm:"type:varchar(20);not null"`
+	Resource         string `gorm:"type:varchar(50);not null"`
+	PatientID        *uint  `gorm:"index"`
+	ResourceID       *uint
+	ParentResourceID *uint
+	Action           AuditAction `gorm:"type:varchar(20);not null"`
+	Fields           []string    `gorm:"serializer:json"`
+	ClientIP         string      `gorm:"type:varchar(64)"`
+	StatusCode       int         `gorm:"not null"`
+	CreatedAt        time.Time   `gorm:"not null;index"`
+	PrevHash         string      `gorm:"type:char(64);not null;default:''"`
+	Hash             string      `gorm:"type:char(64);not null;default:''"`
+}
+
+// AuditCheckpoint is written whenever retention purges the oldest audit
+// events. LastHash is the hash of the last purged event, so verification of
+// the remaining chain can start from it instead of from an empty hash.
+type AuditCheckpoint struct {
+	ID              uint      `gorm:"primaryKey"`
+	PurgedThroughID uint      `gorm:"not null"`
+	LastHash        string    `gorm:"type:char(64);not null"`
+	PurgedCount     int64     `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+// ComputeHash returns the SHA-256 of PrevHash and the event contents. ID is
+// not covered because it is assigned by the database on insert; ordering is
+// protected by the chain itself. The resource IDs are left out when unset so
+// events written before they existed still verify.
+func (e *AuditEvent) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		PrevHash         string      `json:"prev_hash"`
+		ActorID          string      `json:"actor_id"`
+		Role             string      `json:"role"`
+		Resource         string      `json:"resource"`
+		PatientID        *uint       `json:"patient_id"`
+		ResourceID       *uint       `json:"resource_id,omitempty"`
+		ParentResourceID *uint       `json:"parent_resource_id,omitempty"`
+		Action           AuditAction `json:"action"`
+		Fields           []string    `json:"fields"`
+		ClientIP         string      `json:"client_ip"`
+		StatusCode       int         `json:"status_code"`
+		CreatedAt        string      `json:"created_at"`
+	}{
+		PrevHash:         e.PrevHash,
+		ActorID:          e.ActorID,
+		Role:             e.Role,
+		Resource:         e.Resource,
+		PatientID:        e.PatientID,
+		ResourceID:       e.ResourceID,
+		ParentResourceID: e.ParentResourceID,
+		Action:           e.Action,
+		Fields:           e.Fields,
+		ClientIP:         e.ClientIP,
+		StatusCode:       e.StatusCode,
+		CreatedAt:        e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type ExceptionKind string
+
+const (
+	ExceptionLeave   ExceptionKind = "leave"
+	ExceptionHoliday ExceptionKind = "holiday"
+)
+
+// WorkingHours is one entry of a doctor's weekly template. StartTime and
+// EndTime are wall-clock times formatted as "15:04".
+type WorkingHours struct {
+	ID        uint         `gorm:"primaryKey"`
+	DoctorID  uint         `gorm:"not null;index"`
+	Weekday   time.Weekday `gorm:"not null"`
+	StartTime string       `gorm:"type:varchar(5);not null"`
+	EndTime   string       `gorm:"type:varchar(5);not null"`
+	CreatedAt time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt time.Time    `gorm:"autoUpdateTime"`
+}
+
+// AvailabilityException blocks a doctor's calendar regardless of the weekly
+// template.
+type AvailabilityException struct {
+	ID        uint          `gorm:"primaryKey"`
+	DoctorID  uint          `gorm:"not null;index"`
+	Kind      ExceptionKind `gorm:"type:varchar(20);not null"`
+	StartTime time.Time     `gorm:"not null"`
+	EndTime   time.Time     `gorm:"not null"`
+	Reason    string        `gorm:"type:text"`
+	CreatedAt time.Time     `gorm:"autoCreateTime"`
+	UpdatedAt time.Time     `gorm:"autoUpdateTime"`
+}
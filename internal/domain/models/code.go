@@ -0,0 +1,14 @@
+package models
+
+// ICD10Code is an entry in the ICD-10-CM code catalog. Codes are stored in
+// dotted form, e.g. E11.9. Bundled marks codes from the starter subset that
+// ships with the server rather than an imported CMS table.
+type ICD10Code struct {
+	Code        string `gorm:"type:varchar(10);primaryKey"`
+	Description string `gorm:"type:text;not null"`
+	Bundled     bool   `gorm:"not null;default:false"`
+}
+
+func (ICD10Code) TableName() string {
+	return "icd10_codes"
+}
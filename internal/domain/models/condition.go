@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ConditionStatus string
+
+const (
+	ConditionActive   ConditionStatus = "active"
+	ConditionResolved ConditionStatus = "resolved"
+)
+
+// Condition is an entry on a patient's problem list, coded in ICD-10. It
+// supersedes the free-text Patient.MedicalHistory for new data. Deleted
+// entries are soft-deleted so the clinical record stays reconstructible.
+type Condition struct {
+	ID          uint            `gorm:"primaryKey"`
+	PatientID   uint            `gorm:"not null;index"`
+	EncounterID *uint           `gorm:"index"`
+	Code        string          `gorm:"type:varchar(10);not null;index"`
+	Description string          `gorm:"type:text"`
+	OnsetDate   *time.Time      `gorm:"type:date"`
+	Status      ConditionStatus `gorm:"type:varchar(20);not null"`
+	RecordedBy  string          `gorm:"type:varchar(64);not null"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt  `gorm:"index"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type EncounterType string
+
+const (
+	EncounterOffice     EncounterType = "office"
+	EncounterTelehealth EncounterType = "telehealth"
+	EncounterHomeVisit  EncounterType = "home"
+	EncounterEmergency  EncounterType = "emergency"
+)
+
+// Encounter is a visit: the period between a patient checking in with a
+// doctor and checking out. Vitals, notes, conditions and prescriptions
+// recorded during the visit point back to it. AppointmentID is set when the visit was
+// booked; walk-ins have none.
+type Encounter struct {
+	ID            uint          `gorm:"primaryKey"`
+	PatientID     uint          `gorm:"not null;index"`
+	DoctorID      uint          `gorm:"not null;index"`
+	AppointmentID *uint         `gorm:"uniqueIndex"`
+	Type          EncounterType `gorm:"type:varchar(20);not null"`
+	Reason        string        `gorm:"type:text"`
+	CheckInAt     time.Time     `gorm:"not null;index"`
+	CheckOutAt    *time.Time
+	CreatedBy     string    `gorm:"type:varchar(64);not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
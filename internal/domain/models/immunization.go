@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type InjectionSite string
+
+const (
+	SiteLeftArm      InjectionSite = "left_arm"
+	SiteRightArm     InjectionSite = "right_arm"
+	SiteLeftThigh    InjectionSite = "left_thigh"
+	SiteRightThigh   InjectionSite = "right_thigh"
+	SiteLeftGluteal  InjectionSite = "left_gluteal"
+	SiteRightGluteal InjectionSite = "right_gluteal"
+	SiteOral         InjectionSite = "oral"
+	SiteIntranasal   InjectionSite = "intranasal"
+)
+
+// Immunization is one administered vaccine dose. VaccineCode matches a code
+// in the immunization schedule when the vaccine is scheduled; other vaccines,
+// such as travel vaccines, are recorded but not forecast.
+type Immunization struct {
+	ID             uint          `gorm:"primaryKey"`
+	PatientID      uint          `gorm:"not null;index"`
+	VaccineCode    string        `gorm:"type:varchar(20);not null;index"`
+	DoseNumber     int           `gorm:"not null"`
+	AdministeredOn time.Time     `gorm:"type:date;not null"`
+	LotNumber      string        `gorm:"type:varchar(50);not null"`
+	Site           InjectionSite `gorm:"type:varchar(20);not null"`
+	AdministeredBy string        `gorm:"type:varchar(64);not null"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime"`
+}
+
+type ForecastStatus string
+
+const (
+	ForecastUpcoming ForecastStatus = "upcoming"
+	ForecastDue      ForecastStatus = "due"
+	ForecastOverdue  ForecastStatus = "overdue"
+	ForecastComplete ForecastStatus = "complete"
+	// ForecastAgedOut marks a series the patient is now too old to start or
+	// finish.
+	ForecastAgedOut ForecastStatus = "aged_out"
+)
+
+// ForecastItem is the next dose of a scheduled vaccine. DueDate and
+// OverdueDate are unset for complete and aged-out series.
+type ForecastItem struct {
+	VaccineCode string
+	VaccineName string
+	DoseNumber  int
+	Status      ForecastStatus
+	DueDate     *time.Time
+	OverdueDate *time.Time
+}
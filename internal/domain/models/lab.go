@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+type LabPriority string
+
+const (
+	LabRoutine LabPriority = "routine"
+	LabUrgent  LabPriority = "urgent"
+	LabStat    LabPriority = "stat"
+)
+
+type LabOrderStatus string
+
+const (
+	LabOrdered   LabOrderStatus = "ordered"
+	LabCollected LabOrderStatus = "collected"
+	LabResulted  LabOrderStatus = "resulted"
+	LabCancelled LabOrderStatus = "cancelled"
+)
+
+// labOrderTransitions lists the statuses each status may move to.
+var labOrderTransitions = map[LabOrderStatus][]LabOrderStatus{
+	LabOrdered:   {LabCollected, LabCancelled},
+	LabCollected: {LabResulted, LabCancelled},
+}
+
+// CanTransitionTo reports whether a lab order in status s may move to next.
+func (s LabOrderStatus) CanTransitionTo(next LabOrderStatus) bool {
+	for _, allowed := range labOrderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// LabOrder is a test ordered for a patient. OrderingDoctorID is the user who
+// placed the order and is alerted to critical results.
+type LabOrder struct {
+	ID               uint           `gorm:"primaryKey"`
+	PatientID        uint           `gorm:"not null;index"`
+	EncounterID      *uint          `gorm:"index"`
+	TestCode         string         `gorm:"type:varchar(50);not null"`
+	TestName         string         `gorm:"type:varchar(255)"`
+	Priority         LabPriority    `gorm:"type:varchar(20);not null"`
+	OrderingDoctorID uint           `gorm:"not null;index"`
+	Status           LabOrderStatus `gorm:"type:varchar(20);not null;index"`
+	CollectedBy      string         `gorm:"type:varchar(64)"`
+	CollectedAt      *time.Time
+	ResultedBy       string `gorm:"type:varchar(64)"`
+	ResultedAt       *time.Time
+	CancelledBy      string `gorm:"type:varchar(64)"`
+	CancelledAt      *time.Time
+	CancelReason     string      `gorm:"type:text"`
+	Results          []LabResult `gorm:"foreignKey:LabOrderID"`
+	CreatedAt        time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time   `gorm:"autoUpdateTime"`
+}
+
+type LabFlag string
+
+const (
+	LabFlagNormal       LabFlag = "normal"
+	LabFlagLow          LabFlag = "low"
+	LabFlagHigh         LabFlag = "high"
+	LabFlagAbnormal     LabFlag = "abnormal"
+	LabFlagCriticalLow  LabFlag = "critical_low"
+	LabFlagCriticalHigh LabFlag = "critical_high"
+)
+
+// IsCritical reports whether the flag calls for immediate attention.
+func (f LabFlag) IsCritical() bool {
+	return f == LabFlagCriticalLow || f == LabFlagCriticalHigh
+}
+
+// LabResult is one analyte reported for a lab order. Numeric results carry
+// Value; anything else, such as "positive", is kept in ValueText. Missing
+// range bounds are open.
+type LabResult struct {
+	ID            uint      `gorm:"primaryKey"`
+	LabOrderID    uint      `gorm:"not null;index"`
+	PatientID     uint      `gorm:"not null;index"`
+	Analyte       string    `gorm:"type:varchar(100);not null"`
+	Value         *float64  `gorm:"type:numeric"`
+	ValueText     string    `gorm:"type:varchar(255)"`
+	Unit          string    `gorm:"type:varchar(30)"`
+	ReferenceLow  *float64  `gorm:"type:numeric"`
+	ReferenceHigh *float64  `gorm:"type:numeric"`
+	CriticalLow   *float64  `gorm:"type:numeric"`
+	CriticalHigh  *float64  `gorm:"type:numeric"`
+	Flag          LabFlag   `gorm:"type:varchar(20);not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+// ComputeFlag compares a numeric result against its critical and reference
+// ranges. Text results are normal unless the lab flags them.
+func (r *LabResult) ComputeFlag() LabFlag {
+	if r.Value == nil {
+		return LabFlagNormal
+	}
+	v := *r.Value
+	switch {
+	case r.CriticalLow != nil && v <= *r.CriticalLow:
+		return LabFlagCriticalLow
+	case r.CriticalHigh != nil && v >= *r.CriticalHigh:
+		return LabFlagCriticalHigh
+	case r.ReferenceLow != nil && v < *r.ReferenceLow:
+		return LabFlagLow
+	case r.ReferenceHigh != nil && v > *r.ReferenceHigh:
+		return LabFlagHigh
+	}
+	return LabFlagNormal
+}
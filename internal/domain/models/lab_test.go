@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabResult_ComputeFlag(t *testing.T) {
+	refLow, refHigh, critLow, critHigh := 3.5, 5.1, 2.5, 6.5
+	flag := func(value float64) LabFlag {
+		result := &LabResult{Value: &value, ReferenceLow: &refLow, ReferenceHigh: &refHigh, CriticalLow: &critLow, CriticalHigh: &critHigh}
+		return result.ComputeFlag()
+	}
+
+	assert.Equal(t, LabFlagNormal, flag(4.2))
+	assert.Equal(t, LabFlagLow, flag(3.0))
+	assert.Equal(t, LabFlagHigh, flag(5.8))
+	assert.Equal(t, LabFlagCriticalLow, flag(2.5))
+	assert.Equal(t, LabFlagCriticalHigh, flag(6.8))
+	assert.Equal(t, LabFlagNormal, (&LabResult{ValueText: "negative"}).ComputeFlag())
+}
+
+func TestLabOrderStatus_Transitions(t *testing.T) {
+	assert.True(t, LabOrdered.CanTransitionTo(LabCollected))
+	assert.False(t, LabOrdered.CanTransitionTo(LabResulted))
+	assert.True(t, LabCollected.CanTransitionTo(LabCancelled))
+	assert.False(t, LabResulted.CanTransitionTo(LabCancelled))
+}
@@ -0,0 +1,144 @@
+package models
+
+import "gorm.io/gorm"
+
+// AutoMigrate creates or updates the schema for every model, then applies
+// the constraints, indexes and triggers GORM tags cannot express.
+func AutoMigrate(db *gorm.DB) error {
+	// pg_trgm and fuzzystrmatch back the fuzzy patient name search
+	for _, extension := range []string{"pg_trgm", "fuzzystrmatch"} {
+		if err := db.Exec("CREATE EXTENSION IF NOT EXISTS " + extension).Error; err != nil {
+			return err
+		}
+	}
+
+	err := db.AutoMigrate(
+		User{}, Patient{}, PatientTombstone{}, PatientVersion{},
+		Appointment{}, WorkingHours{}, AvailabilityException{},
+		AuditEvent{}, AuditCheckpoint{}, RetentionRun{},
+		Condition{}, ICD10Code{}, Allergy{}, Prescription{}, Vital{},
+		VitalRule{}, WorklistItem{}, Encounter{}, ClinicalNote{},
+		LabOrder{}, LabResult{}, Immunization{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := migratePatientNameSearch(db); err != nil {
+		return err
+	}
+	if err := migratePatientEmail(db); err != nil {
+		return err
+	}
+	if err := migrateAppointmentOverlap(db); err != nil {
+		return err
+	}
+	if err := migrateAuditImmutability(db); err != nil {
+		return err
+	}
+	return migrateNoteImmutability(db)
+}
+
+// migratePatientNameSearch adds the trigram index the fuzzy patient name
+// search runs on.
+func migratePatientNameSearch(db *gorm.DB) error {
+	return db.Exec(`CREATE INDEX IF NOT EXISTS idx_patients_name_trgm
+		ON patients USING gin ((first_name || ' ' || last_name) gin_trgm_ops)`).Error
+}
+
+// migratePatientEmail drops the table-wide unique constraint earlier schemas
+// put on patients.email. PatientEmailIndex replaces it and ignores archived
+// patients.
+func migratePatientEmail(db *gorm.DB) error {
+	for _, constraint := range []string{"uni_patients_email", "patients_email_key"} {
+		if err := db.Exec(`ALTER TABLE patients DROP CONSTRAINT IF EXISTS ` + constraint).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAppointmentOverlap adds the exclusion constraint that keeps a
+// doctor's scheduled appointments from overlapping, so concurrent bookings
+// cannot both succeed. Cancelled appointments are not covered.
+func migrateAppointmentOverlap(db *gorm.DB) error {
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS btree_gist`).Error; err != nil {
+		return err
+	}
+
+	var exists bool
+	err := db.Raw(`SELECT EXISTS(SELECT 1 FROM pg_constraint WHERE conname = ?)`, AppointmentOverlapConstraint).
+		Scan(&exists).Error
+	if err != nil || exists {
+		return err
+	}
+
+	return db.Exec(`ALTER TABLE appointments ADD CONSTRAINT ` + AppointmentOverlapConstraint + `
+		EXCLUDE USING gist (doctor_id WITH =, tstzrange(start_time, end_time) WITH &&)
+		WHERE (status = 'scheduled')`).Error
+}
+
+// migrateAuditImmutability installs a trigger that rejects any UPDATE or
+// DELETE on audit_events, so the log can only be appended to. The retention
+// purge is the one exception: it deletes the oldest events inside a
+// transaction that sets app.audit_purge.
+func migrateAuditImmutability(db *gorm.DB) error {
+	err := db.Exec(`CREATE OR REPLACE FUNCTION audit_events_immutable() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' AND current_setting('app.audit_purge', true) = 'on' THEN
+				RETURN OLD;
+			END IF;
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Exec(`DROP TRIGGER IF EXISTS audit_events_immutable ON audit_events`).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE TRIGGER audit_events_immutable
+		BEFORE UPDATE OR DELETE ON audit_events
+		FOR EACH ROW EXECUTE FUNCTION audit_events_immutable()`).Error
+}
+
+// migrateNoteImmutability installs a trigger that rejects changes to the
+// content of a signed clinical note, and its deletion. Re-pointing patient_id
+// when patients are merged is still allowed, as is deleting signed notes
+// inside a patient purge, which sets app.patient_purge.
+func migrateNoteImmutability(db *gorm.DB) error {
+	err := db.Exec(`CREATE OR REPLACE FUNCTION clinical_notes_immutable() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				IF OLD.status = 'signed' AND current_setting('app.patient_purge', true) IS DISTINCT FROM 'on' THEN
+					RAISE EXCEPTION 'signed clinical notes are immutable';
+				END IF;
+				RETURN OLD;
+			END IF;
+			IF OLD.status = 'signed' AND
+				(NEW.encounter_id, NEW.addendum_to, NEW.subjective, NEW.objective, NEW.assessment, NEW.plan,
+				 NEW.status, NEW.author_id, NEW.signed_by, NEW.signed_at)
+				IS DISTINCT FROM
+				(OLD.encounter_id, OLD.addendum_to, OLD.subjective, OLD.objective, OLD.assessment, OLD.plan,
+				 OLD.status, OLD.author_id, OLD.signed_by, OLD.signed_at) THEN
+				RAISE EXCEPTION 'signed clinical notes are immutable';
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Exec(`DROP TRIGGER IF EXISTS clinical_notes_immutable ON clinical_notes`).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE TRIGGER clinical_notes_immutable
+		BEFORE UPDATE OR DELETE ON clinical_notes
+		FOR EACH ROW EXECUTE FUNCTION clinical_notes_immutable()`).Error
+}
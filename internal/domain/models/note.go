@@ -0,0 +1,39 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type NoteStatus string
+
+const (
+	NoteDraft  NoteStatus = "draft"
+	NoteSigned NoteStatus = "signed"
+)
+
+// ClinicalNote is a SOAP note written during an encounter. Drafts may be
+// edited by their author; signing makes the note immutable. Corrections to a
+// signed note are made with addenda: further notes whose AddendumTo points at
+// the original.
+type ClinicalNote struct {
+	ID          uint       `gorm:"primaryKey"`
+	PatientID   uint       `gorm:"not null;index"`
+	EncounterID uint       `gorm:"not null;index"`
+	AddendumTo  *uint      `gorm:"index"`
+	Subjective  string     `gorm:"type:text"`
+	Objective   string     `gorm:"type:text"`
+	Assessment  string     `gorm:"type:text"`
+	Plan        string     `gorm:"type:text"`
+	Status      NoteStatus `gorm:"type:varchar(20);not null"`
+	AuthorID    string     `gorm:"type:varchar(64);not null"`
+	SignedBy    string     `gorm:"type:varchar(64)"`
+	SignedAt    *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// IsEmpty reports whether every SOAP section is blank.
+func (n *ClinicalNote) IsEmpty() bool {
+	return strings.TrimSpace(n.Subjective+n.Objective+n.Assessment+n.Plan) == ""
+}
@@ -2,18 +2,46 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// PatientEmailIndex keeps emails unique among patients that are not archived,
+// so a person whose old record was archived can be registered again.
+const PatientEmailIndex = "idx_patients_email"
+
+// Patient is soft deleted: archiving sets DeletedAt, which hides the record from
+// normal queries until it is restored or purged.
 type Patient struct {
 	ID             uint      `gorm:"primaryKey"`
 	FirstName      string    `gorm:"type:varchar(255);not null"`
 	LastName       string    `gorm:"type:varchar(255);not null"`
 	DOB            time.Time `gorm:"not null"`
-	Email          *string   `gorm:"unique"`
+	Email          *string   `gorm:"uniqueIndex:idx_patients_email,where:deleted_at IS NULL"`
 	Gender         string    `gorm:"type:varchar(255);not null"`
 	PhoneNumber    string    `gorm:"not null"`
 	Address        string    `gorm:"not null"`
 	MedicalHistory string    `gorm:"type:text; not null"`
-	CreatedAt      time.Time `gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	Version        uint      `gorm:"not null;default:1"`
+	AnonymisedAt   *time.Time
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// PatientTombstone records that a patient was merged into another record so
+// that lookups of the old ID can be redirected.
+type PatientTombstone struct {
+	MergedID uint      `gorm:"primaryKey;autoIncrement:false"`
+	TargetID uint      `gorm:"not null;index"`
+	MergedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// AgeAt returns the patient's age in whole years at t.
+func (p *Patient) AgeAt(t time.Time) int {
+	age := t.Year() - p.DOB.Year()
+	if t.Month() < p.DOB.Month() || (t.Month() == p.DOB.Month() && t.Day() < p.DOB.Day()) {
+		age--
+	}
+	return age
 }
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"time"
+)
+
+type PatientChangeType string
+
+const (
+	PatientChangeBaseline  PatientChangeType = "baseline"
+	PatientChangeCreate    PatientChangeType = "create"
+	PatientChangeUpdate    PatientChangeType = "update"
+	PatientChangeMerge     PatientChangeType = "merge"
+	PatientChangeArchive   PatientChangeType = "archive"
+	PatientChangeRestore   PatientChangeType = "restore"
+	PatientChangeAnonymise PatientChangeType = "anonymise"
+)
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// PatientVersion is a full snapshot of a patient row taken after every change,
+// together with the field-level diff against the previous snapshot. A
+// baseline version is written the first time a patient created before
+// history tracking is changed.
+type PatientVersion struct {
+	ID         uint              `gorm:"primaryKey"`
+	PatientID  uint              `gorm:"not null;uniqueIndex:idx_patient_version"`
+	Version    int               `gorm:"not null;uniqueIndex:idx_patient_version"`
+	ChangeType PatientChangeType `gorm:"type:varchar(20);not null"`
+	ChangedBy  string            `gorm:"type:varchar(64)"`
+	Changes    []FieldChange     `gorm:"serializer:json"`
+	Snapshot   Patient           `gorm:"serializer:json;not null"`
+	CreatedAt  time.Time         `gorm:"not null;index"`
+}
+
+// Diff lists the fields that differ between p and next. A nil p is treated as
+// a record that did not exist yet, so every field of next is reported.
+func (p *Patient) Diff(next *Patient) []FieldChange {
+	var old map[string]any
+	if p != nil {
+		old = p.trackedFields()
+	}
+	current := next.trackedFields()
+
+	changes := []FieldChange{}
+	for _, field := range trackedPatientFields {
+		newValue := current[field]
+		oldValue, existed := old[field]
+		if existed && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+// trackedPatientFields fixes the order in which diffs are reported.
+var trackedPatientFields = []string{
+	"first_name", "last_name", "dob", "email", "gender",
+	"phone_number", "address", "medical_history",
+}
+
+func (p *Patient) trackedFields() map[string]any {
+	var email any
+	if p.Email != nil {
+		email = *p.Email
+	}
+	return map[string]any{
+		"first_name":      p.FirstName,
+		"last_name":       p.LastName,
+		"dob":             p.DOB.Format("2006-01-02"),
+		"email":           email,
+		"gender":          p.Gender,
+		"phone_number":    p.PhoneNumber,
+		"address":         p.Address,
+		"medical_history": p.MedicalHistory,
+	}
+}
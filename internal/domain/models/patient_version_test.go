@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientDiff_ReportsChangedFields(t *testing.T) {
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := &Patient{FirstName: "Jon", LastName: "Doe", DOB: dob, Address: "Old Street"}
+	after := &Patient{FirstName: "John", LastName: "Doe", DOB: dob, Address: "Old Street"}
+
+	changes := before.Diff(after)
+
+	assert.Equal(t, []FieldChange{{Field: "first_name", Old: "Jon", New: "John"}}, changes)
+}
+
+func TestPatientDiff_NilBeforeReportsEveryField(t *testing.T) {
+	changes := (*Patient)(nil).Diff(&Patient{FirstName: "Jane"})
+
+	assert.Len(t, changes, len(trackedPatientFields))
+	assert.Equal(t, "first_name", changes[0].Field)
+	assert.Nil(t, changes[0].Old)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PrescriptionStatus string
+
+const (
+	PrescriptionDraft        PrescriptionStatus = "draft"
+	PrescriptionSigned       PrescriptionStatus = "signed"
+	PrescriptionDispensed    PrescriptionStatus = "dispensed"
+	PrescriptionDiscontinued PrescriptionStatus = "discontinued"
+)
+
+// prescriptionTransitions lists the statuses each status may move to.
+var prescriptionTransitions = map[PrescriptionStatus][]PrescriptionStatus{
+	PrescriptionDraft:     {PrescriptionSigned},
+	PrescriptionSigned:    {PrescriptionDispensed, PrescriptionDiscontinued},
+	PrescriptionDispensed: {PrescriptionDiscontinued},
+}
+
+// CanTransitionTo reports whether a prescription in status s may move to
+// next.
+func (s PrescriptionStatus) CanTransitionTo(next PrescriptionStatus) bool {
+	for _, allowed := range prescriptionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type InteractionSeverity string
+
+const (
+	InteractionLow      InteractionSeverity = "low"
+	InteractionModerate InteractionSeverity = "moderate"
+	InteractionHigh     InteractionSeverity = "high"
+)
+
+type InteractionType string
+
+const (
+	InteractionDrugAllergy InteractionType = "drug-allergy"
+	InteractionDrugDrug    InteractionType = "drug-drug"
+)
+
+// InteractionWarning is a conflict found when the prescription was checked
+// against the patient's allergies and active medications. Conflict names the
+// allergy substance or the other drug.
+type InteractionWarning struct {
+	Type        InteractionType     `json:"type"`
+	Severity    InteractionSeverity `json:"severity"`
+	Conflict    string              `json:"conflict"`
+	Description string              `json:"description"`
+}
+
+// ActivePrescriptionStatuses are the statuses of medications the patient is
+// currently taking.
+var ActivePrescriptionStatuses = []PrescriptionStatus{PrescriptionSigned, PrescriptionDispensed}
+
+// Prescription is a medication order for a patient. Only drafts may be edited
+// or deleted; signing locks the order.
+type Prescription struct {
+	ID                 uint   `gorm:"primaryKey"`
+	PatientID          uint   `gorm:"not null;index"`
+	EncounterID        *uint  `gorm:"index"`
+	Drug               string `gorm:"type:varchar(255);not null"`
+	Dose               string `gorm:"type:varchar(100);not null"`
+	Route              string `gorm:"type:varchar(50);not null"`
+	Frequency          string `gorm:"type:varchar(100);not null"`
+	DurationDays       *int
+	Status             PrescriptionStatus `gorm:"type:varchar(20);not null;index"`
+	PrescribedBy       string             `gorm:"type:varchar(64);not null"`
+	SignedBy           string             `gorm:"type:varchar(64)"`
+	SignedAt           *time.Time
+	DispensedBy        string `gorm:"type:varchar(64)"`
+	DispensedAt        *time.Time
+	DiscontinuedBy     string `gorm:"type:varchar(64)"`
+	DiscontinuedAt     *time.Time
+	DiscontinuedReason string `gorm:"type:text"`
+	// InteractionWarnings are the conflicts found when the drug was last set.
+	// High-severity conflicts are only accepted with an OverrideReason.
+	InteractionWarnings []InteractionWarning `gorm:"serializer:json"`
+	OverrideReason      string               `gorm:"type:text"`
+	CreatedAt           time.Time            `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time            `gorm:"autoUpdateTime"`
+	DeletedAt           gorm.DeletedAt       `gorm:"index"`
+}
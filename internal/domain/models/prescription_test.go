@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrescriptionStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, PrescriptionDraft.CanTransitionTo(PrescriptionSigned))
+	assert.True(t, PrescriptionSigned.CanTransitionTo(PrescriptionDispensed))
+	assert.True(t, PrescriptionDispensed.CanTransitionTo(PrescriptionDiscontinued))
+
+	assert.False(t, PrescriptionDraft.CanTransitionTo(PrescriptionDispensed))
+	assert.False(t, PrescriptionSigned.CanTransitionTo(PrescriptionDraft))
+	assert.False(t, PrescriptionDiscontinued.CanTransitionTo(PrescriptionSigned))
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type RetentionAction string
+
+const (
+	RetentionPurge     RetentionAction = "purge"
+	RetentionAnonymise RetentionAction = "anonymise"
+)
+
+// RetentionRuleResult describes what one retention rule did, or would have
+// done in a dry run.
+type RetentionRuleResult struct {
+	Rule       string          `json:"rule"`
+	Action     RetentionAction `json:"action"`
+	Cutoff     time.Time       `json:"cutoff"`
+	Affected   int64           `json:"affected"`
+	PatientIDs []uint          `json:"patient_ids,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// RetentionRun is the report of a single pass of the retention engine.
+type RetentionRun struct {
+	ID          uint                  `gorm:"primaryKey"`
+	DryRun      bool                  `gorm:"not null"`
+	TriggeredBy string                `gorm:"type:varchar(64);not null"`
+	Results     []RetentionRuleResult `gorm:"serializer:json"`
+	StartedAt   time.Time             `gorm:"not null;index"`
+	FinishedAt  time.Time             `gorm:"not null"`
+}
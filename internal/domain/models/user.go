@@ -1,16 +1,14 @@
 package models
 
-import (
-	"time"
-
-	"gorm.io/gorm"
-)
+import "time"
 
 type Role string
 
 const (
-	Clerk Role = "receptionist"
-	Doc   Role = "doctor"
+	Clerk      Role = "receptionist"
+	Doc        Role = "doctor"
+	Pharmacist Role = "pharmacist"
+	Admin      Role = "admin"
 )
 
 type User struct {
@@ -21,7 +19,3 @@ type User struct {
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
-
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(User{}, Patient{})
-}
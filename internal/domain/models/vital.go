@@ -0,0 +1,108 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// VitalType names one kind of measurement in a vitals reading. BMI is not
+// stored; it is derived from weight and height.
+type VitalType string
+
+const (
+	VitalBloodPressure VitalType = "blood_pressure"
+	VitalHeartRate     VitalType = "heart_rate"
+	VitalTemperature   VitalType = "temperature"
+	VitalSpO2          VitalType = "spo2"
+	VitalWeight        VitalType = "weight"
+	VitalHeight        VitalType = "height"
+	VitalBMI           VitalType = "bmi"
+)
+
+// Vital is one set of measurements taken from a patient at a point in time.
+// Any measurement not taken is left nil. Flags lists the measurements that
+// were abnormal under the rules in force when it was recorded.
+type Vital struct {
+	ID           uint  `gorm:"primaryKey"`
+	PatientID    uint  `gorm:"not null;index:idx_vitals_patient_recorded,priority:1"`
+	EncounterID  *uint `gorm:"index"`
+	SystolicBP   *int
+	DiastolicBP  *int
+	HeartRate    *int
+	TemperatureC *float64
+	SpO2         *int `gorm:"column:spo2"`
+	WeightKg     *float64
+	HeightCm     *float64
+	RecordedAt   time.Time   `gorm:"not null;index:idx_vitals_patient_recorded,priority:2"`
+	RecordedBy   string      `gorm:"type:varchar(64);not null"`
+	Flags        []VitalFlag `gorm:"serializer:json"`
+	CreatedAt    time.Time   `gorm:"autoCreateTime"`
+}
+
+// HasMeasurements reports whether at least one measurement was taken.
+func (v *Vital) HasMeasurements() bool {
+	return v.SystolicBP != nil || v.DiastolicBP != nil || v.HeartRate != nil || v.TemperatureC != nil ||
+		v.SpO2 != nil || v.WeightKg != nil || v.HeightCm != nil
+}
+
+// ComputeBMI returns the reading's BMI using its own height or, when none
+// was taken, the latest height in history (ordered oldest first) recorded at
+// or before it. It is nil when there is no weight or no usable height.
+func (v *Vital) ComputeBMI(history []Vital) *float64 {
+	if v.WeightKg == nil {
+		return nil
+	}
+
+	height := v.HeightCm
+	if height == nil {
+		for i := range history {
+			if history[i].RecordedAt.After(v.RecordedAt) {
+				break
+			}
+			if history[i].HeightCm != nil {
+				height = history[i].HeightCm
+			}
+		}
+	}
+	if height == nil {
+		return nil
+	}
+
+	bmi := BMI(*v.WeightKg, *height)
+	return &bmi
+}
+
+// BMI returns the body mass index for a weight in kilograms and a height in
+// centimetres, rounded to one decimal place.
+func BMI(weightKg, heightCm float64) float64 {
+	heightM := heightCm / 100
+	return math.Round(weightKg/(heightM*heightM)*10) / 10
+}
+
+// Measurements returns the values taken in this reading keyed by
+// measurement name. BMI is not included.
+func (v *Vital) Measurements() map[string]float64 {
+	values := map[string]float64{}
+	if v.SystolicBP != nil {
+		values[MeasurementSystolicBP] = float64(*v.SystolicBP)
+	}
+	if v.DiastolicBP != nil {
+		values[MeasurementDiastolicBP] = float64(*v.DiastolicBP)
+	}
+	if v.HeartRate != nil {
+		values[MeasurementHeartRate] = float64(*v.HeartRate)
+	}
+	if v.TemperatureC != nil {
+		values[MeasurementTemperatureC] = *v.TemperatureC
+	}
+	if v.SpO2 != nil {
+		values[MeasurementSpO2] = float64(*v.SpO2)
+	}
+	if v.WeightKg != nil {
+		values[MeasurementWeightKg] = *v.WeightKg
+	}
+	if v.HeightCm != nil {
+		values[MeasurementHeightCm] = *v.HeightCm
+	}
+	return values
+}
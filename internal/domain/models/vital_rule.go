@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Measurements a vital rule can apply to. The names match the JSON keys of
+// the vitals DTOs.
+const (
+	MeasurementSystolicBP   = "systolic_bp"
+	MeasurementDiastolicBP  = "diastolic_bp"
+	MeasurementHeartRate    = "heart_rate"
+	MeasurementTemperatureC = "temperature_c"
+	MeasurementSpO2         = "spo2"
+	MeasurementWeightKg     = "weight_kg"
+	MeasurementHeightCm     = "height_cm"
+	MeasurementBMI          = "bmi"
+)
+
+// VitalRule flags a measurement as abnormal when it falls below Low or above
+// High for patients aged at least MinAgeYears and, when MaxAgeYears is set,
+// younger than MaxAgeYears.
+type VitalRule struct {
+	ID          uint   `gorm:"primaryKey"`
+	Measurement string `gorm:"type:varchar(20);not null;index"`
+	MinAgeYears int    `gorm:"not null;default:0"`
+	MaxAgeYears *int
+	Low         *float64
+	High        *float64
+	Description string    `gorm:"type:text"`
+	UpdatedBy   string    `gorm:"type:varchar(64)"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// AppliesToAge reports whether the rule covers a patient of the given age.
+func (r *VitalRule) AppliesToAge(age int) bool {
+	return age >= r.MinAgeYears && (r.MaxAgeYears == nil || age < *r.MaxAgeYears)
+}
+
+// IsAbnormal reports whether value lies outside the rule's range.
+func (r *VitalRule) IsAbnormal(value float64) bool {
+	return (r.Low != nil && value < *r.Low) || (r.High != nil && value > *r.High)
+}
+
+// VitalFlag records a measurement that broke a rule when it was recorded.
+// The range is copied so later rule changes do not alter past flags.
+type VitalFlag struct {
+	Measurement string   `json:"measurement"`
+	Value       float64  `json:"value"`
+	Low         *float64 `json:"low,omitempty"`
+	High        *float64 `json:"high,omitempty"`
+	RuleID      uint     `json:"rule_id"`
+	Description string   `json:"description,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatient_AgeAt(t *testing.T) {
+	patient := &Patient{DOB: time.Date(2010, 6, 15, 0, 0, 0, 0, time.UTC)}
+
+	assert.Equal(t, 14, patient.AgeAt(time.Date(2025, 6, 14, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 15, patient.AgeAt(time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestVitalRule_AgeBandAndRange(t *testing.T) {
+	low, high, maxAge := 55.0, 120.0, 18
+	rule := &VitalRule{Measurement: MeasurementHeartRate, MinAgeYears: 12, MaxAgeYears: &maxAge, Low: &low, High: &high}
+
+	assert.True(t, rule.AppliesToAge(12))
+	assert.False(t, rule.AppliesToAge(18))
+	assert.True(t, rule.IsAbnormal(50))
+	assert.True(t, rule.IsAbnormal(121))
+	assert.False(t, rule.IsAbnormal(120))
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type WorklistItemKind string
+
+const (
+	WorklistAbnormalVitals WorklistItemKind = "abnormal_vitals"
+	WorklistCriticalLab    WorklistItemKind = "critical_lab"
+)
+
+type WorklistItemStatus string
+
+const (
+	WorklistOpen         WorklistItemStatus = "open"
+	WorklistAcknowledged WorklistItemStatus = "acknowledged"
+)
+
+// WorklistItem is something a doctor needs to act on, such as an abnormal
+// reading or a critical lab result for one of their patients. SourceID points at the record that
+// raised it, interpreted according to Kind.
+type WorklistItem struct {
+	ID             uint               `gorm:"primaryKey"`
+	DoctorID       uint               `gorm:"not null;index"`
+	PatientID      uint               `gorm:"not null;index"`
+	Kind           WorklistItemKind   `gorm:"type:varchar(30);not null"`
+	SourceID       uint               `gorm:"not null"`
+	Summary        string             `gorm:"type:text;not null"`
+	Status         WorklistItemStatus `gorm:"type:varchar(20);not null;index"`
+	AcknowledgedAt *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type allergyRepository struct {
+	db *gorm.DB
+}
+
+func NewAllergyRepository(db *gorm.DB) *allergyRepository {
+	return &allergyRepository{
+		db: db,
+	}
+}
+
+func (r *allergyRepository) CreateAllergy(allergy *models.Allergy) (*models.Allergy, error) {
+	if err := r.db.Create(allergy).Error; err != nil {
+		return nil, err
+	}
+	return allergy, nil
+}
+
+func (r *allergyRepository) GetAllergyById(patientID, id uint) (*models.Allergy, error) {
+	var allergy models.Allergy
+
+	err := r.db.Where("patient_id = ?", patientID).First(&allergy, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &allergy, nil
+}
+
+// ListAllergies returns the patient's allergies, most severe first.
+func (r *allergyRepository) ListAllergies(patientID uint) ([]models.Allergy, error) {
+	var allergies []models.Allergy
+
+	err := r.db.Where("patient_id = ?", patientID).
+		Order("CASE severity WHEN 'severe' THEN 0 WHEN 'moderate' THEN 1 ELSE 2 END").
+		Order("substance").Find(&allergies).Error
+	if err != nil {
+		return nil, err
+	}
+	return allergies, nil
+}
+
+func (r *allergyRepository) UpdateAllergyById(patientID, id uint, updates map[string]interface{}) (*models.Allergy, error) {
+	var allergy models.Allergy
+
+	result := r.db.Model(&allergy).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ?", patientID, id).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &allergy, nil
+}
+
+func (r *allergyRepository) DeleteAllergyById(patientID, id uint) error {
+	result := r.db.Where("patient_id = ?", patientID).Delete(&models.Allergy{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
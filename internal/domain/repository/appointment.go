@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAppointmentOverlap is returned when a write would give a doctor two
+// scheduled appointments at the same time. The database enforces this, so it
+// also catches concurrent bookings that both passed HasOverlappingAppointment.
+var ErrAppointmentOverlap = errors.New("appointment overlaps another")
+
+type AppointmentFilter struct {
+	PatientID uint
+	DoctorID  uint
+	Status    models.AppointmentStatus
+	From      *time.Time
+	To        *time.Time
+}
+
+type appointmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAppointmentRepository(db *gorm.DB) *appointmentRepository {
+	return &appointmentRepository{
+		db: db,
+	}
+}
+
+func (r *appointmentRepository) CreateAppointment(appointment *models.Appointment) (*models.Appointment, error) {
+	result := r.db.Create(appointment)
+
+	if result.Error != nil {
+		if isConstraintViolation(result.Error, models.AppointmentOverlapConstraint) {
+			return nil, ErrAppointmentOverlap
+		}
+		return nil, result.Error
+	}
+
+	return appointment, nil
+}
+
+func (r *appointmentRepository) GetAppointmentById(id uint) (*models.Appointment, error) {
+	var appointment models.Appointment
+
+	err := r.db.First(&appointment, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &appointment, nil
+}
+
+func (r *appointmentRepository) UpdateAppointmentById(id uint, updates map[string]interface{}) (*models.Appointment, error) {
+	var appointment models.Appointment
+
+	err := r.db.Model(&appointment).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		if isConstraintViolation(err, models.AppointmentOverlapConstraint) {
+			return nil, ErrAppointmentOverlap
+		}
+		return nil, err
+	}
+	return &appointment, nil
+}
+
+func (r *appointmentRepository) ListAppointments(filter AppointmentFilter) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+
+	query := r.db.Model(&models.Appointment{})
+	if filter.PatientID != 0 {
+		query = query.Where("patient_id = ?", filter.PatientID)
+	}
+	if filter.DoctorID != 0 {
+		query = query.Where("doctor_id = ?", filter.DoctorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("end_time > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+
+	err := query.Order("start_time").Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// HasOverlappingAppointment reports whether the doctor already has a scheduled
+// appointment intersecting [start, end). excludeID skips the appointment being
+// rescheduled.
+func (r *appointmentRepository) HasOverlappingAppointment(doctorID uint, start, end time.Time, excludeID uint) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM appointments WHERE doctor_id = ? AND status = ? AND start_time < ? AND end_time > ? AND id <> ?)`
+	err := r.db.Raw(query, doctorID, models.AppointmentScheduled, end, start, excludeID).Scan(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// isConstraintViolation reports whether err is a Postgres error raised by the
+// named constraint.
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type AuditFilter struct {
+	PatientID uint
+	ActorID   string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *auditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// CreateAuditEvent links the event to the current tail of the chain and
+// inserts it. The table lock serialises writers so two events can never claim
+// the same predecessor.
+func (r *auditRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE audit_events IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var last models.AuditEvent
+		err := tx.Order("id DESC").Limit(1).Find(&last).Error
+		if err != nil {
+			return err
+		}
+
+		event.PrevHash = last.Hash
+		event.Hash = event.ComputeHash()
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (r *auditRepository) ListAuditEvents(filter AuditFilter) ([]models.AuditEvent, int64, error) {
+	var events []models.AuditEvent
+	var total int64
+
+	query := r.db.Model(&models.AuditEvent{})
+	if filter.PatientID != 0 {
+		query = query.Where("patient_id = ?", filter.PatientID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// WalkAuditEvents calls fn with every audit event in insertion order, batchSize
+// rows at a time.
+func (r *auditRepository) WalkAuditEvents(batchSize int, fn func(events []models.AuditEvent) error) error {
+	var events []models.AuditEvent
+
+	return r.db.Order("id").FindInBatches(&events, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(events)
+	}).Error
+}
+
+func (r *auditRepository) GetLatestAuditCheckpoint() (*models.AuditCheckpoint, error) {
+	var checkpoint models.AuditCheckpoint
+
+	err := r.db.Order("id DESC").First(&checkpoint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// CountAuditEventsBefore counts the events PurgeAuditEventsBefore would
+// remove for the same cutoff.
+func (r *auditRepository) CountAuditEventsBefore(cutoff time.Time) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&models.AuditEvent{}).
+		Where("id <= (SELECT COALESCE(MAX(id), 0) FROM audit_events WHERE created_at < ?)", cutoff).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// PurgeAuditEventsBefore deletes the oldest audit events, up to the newest one
+// created before cutoff. Only a prefix of the chain is ever removed, and the
+// hash of the last removed event is kept in a checkpoint so the remaining
+// chain still verifies. The immutability trigger lets the delete through
+// because the transaction sets app.audit_purge.
+func (r *auditRepository) PurgeAuditEventsBefore(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE audit_events IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var last models.AuditEvent
+		err := tx.Where("created_at < ?", cutoff).Order("id DESC").Limit(1).Find(&last).Error
+		if err != nil || last.ID == 0 {
+			return err
+		}
+
+		var previous models.AuditCheckpoint
+		if err := tx.Order("id DESC").Limit(1).Find(&previous).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("SET LOCAL app.audit_purge = 'on'").Error; err != nil {
+			return err
+		}
+		result := tx.Where("id <= ?", last.ID).Delete(&models.AuditEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+
+		return tx.Create(&models.AuditCheckpoint{
+			PurgedThroughID: last.ID,
+			LastHash:        last.Hash,
+			PurgedCount:     previous.PurgedCount + purged,
+		}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
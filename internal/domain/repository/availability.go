@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type availabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *availabilityRepository {
+	return &availabilityRepository{
+		db: db,
+	}
+}
+
+func (r *availabilityRepository) ReplaceWorkingHours(doctorID uint, hours []models.WorkingHours) ([]models.WorkingHours, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("doctor_id = ?", doctorID).Delete(&models.WorkingHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+func (r *availabilityRepository) GetWorkingHours(doctorID uint) ([]models.WorkingHours, error) {
+	var hours []models.WorkingHours
+
+	err := r.db.Where("doctor_id = ?", doctorID).Order("weekday, start_time").Find(&hours).Error
+	if err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+func (r *availabilityRepository) CreateException(exception *models.AvailabilityException) (*models.AvailabilityException, error) {
+	result := r.db.Create(exception)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return exception, nil
+}
+
+func (r *availabilityRepository) ListExceptions(doctorID uint, from, to time.Time) ([]models.AvailabilityException, error) {
+	var exceptions []models.AvailabilityException
+
+	err := r.db.Where("doctor_id = ? AND start_time < ? AND end_time > ?", doctorID, to, from).
+		Order("start_time").Find(&exceptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return exceptions, nil
+}
+
+func (r *availabilityRepository) DeleteException(doctorID, id uint) error {
+	result := r.db.Where("doctor_id = ?", doctorID).Delete(&models.AvailabilityException{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
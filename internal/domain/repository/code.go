@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type codeRepository struct {
+	db *gorm.DB
+}
+
+func NewCodeRepository(db *gorm.DB) *codeRepository {
+	return &codeRepository{
+		db: db,
+	}
+}
+
+// ReplaceICD10Codes swaps the whole catalog for codes in one transaction.
+// Conditions already coded against a dropped code keep their value.
+func (r *codeRepository) ReplaceICD10Codes(codes []models.ICD10Code) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.ICD10Code{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(codes, 1000).Error
+	})
+}
+
+func (r *codeRepository) CountICD10Codes() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ICD10Code{}).Count(&count).Error
+	return count, err
+}
+
+func (r *codeRepository) CountImportedICD10Codes() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ICD10Code{}).Where("bundled = ?", false).Count(&count).Error
+	return count, err
+}
+
+func (r *codeRepository) GetICD10Code(code string) (*models.ICD10Code, error) {
+	var icd10Code models.ICD10Code
+
+	err := r.db.Where("code = ?", code).First(&icd10Code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &icd10Code, nil
+}
+
+// SearchICD10Codes matches codes starting with term, ignoring dots, and codes
+// whose description contains every word of term. Code matches sort first.
+func (r *codeRepository) SearchICD10Codes(term string, limit int) ([]models.ICD10Code, error) {
+	var codes []models.ICD10Code
+
+	codePrefix := escapeLike(strings.ToUpper(strings.ReplaceAll(term, ".", ""))) + "%"
+
+	keywords := r.db
+	for _, word := range strings.Fields(term) {
+		keywords = keywords.Where("description ILIKE ?", "%"+escapeLike(word)+"%")
+	}
+
+	err := r.db.Where("replace(code, '.', '') LIKE ?", codePrefix).Or(keywords).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "CASE WHEN replace(code, '.', '') LIKE ? THEN 0 ELSE 1 END, code",
+			Vars:               []interface{}{codePrefix},
+			WithoutParentheses: true,
+		}}).Limit(limit).Find(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type conditionRepository struct {
+	db *gorm.DB
+}
+
+func NewConditionRepository(db *gorm.DB) *conditionRepository {
+	return &conditionRepository{
+		db: db,
+	}
+}
+
+func (r *conditionRepository) CreateCondition(condition *models.Condition) (*models.Condition, error) {
+	if err := r.db.Create(condition).Error; err != nil {
+		return nil, err
+	}
+	return condition, nil
+}
+
+func (r *conditionRepository) GetConditionById(patientID, id uint) (*models.Condition, error) {
+	var condition models.Condition
+
+	err := r.db.Where("patient_id = ?", patientID).First(&condition, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &condition, nil
+}
+
+// ListConditions returns the patient's problem list, active entries first. An
+// empty status returns every entry.
+func (r *conditionRepository) ListConditions(patientID uint, status models.ConditionStatus) ([]models.Condition, error) {
+	var conditions []models.Condition
+
+	query := r.db.Where("patient_id = ?", patientID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("status ASC").Order("onset_date DESC NULLS LAST").Order("id ASC").Find(&conditions).Error
+	if err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
+
+func (r *conditionRepository) UpdateConditionById(patientID, id uint, updates map[string]interface{}) (*models.Condition, error) {
+	var condition models.Condition
+
+	result := r.db.Model(&condition).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ?", patientID, id).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &condition, nil
+}
+
+func (r *conditionRepository) DeleteConditionById(patientID, id uint) error {
+	result := r.db.Where("patient_id = ?", patientID).Delete(&models.Condition{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type encounterRepository struct {
+	db *gorm.DB
+}
+
+func NewEncounterRepository(db *gorm.DB) *encounterRepository {
+	return &encounterRepository{
+		db: db,
+	}
+}
+
+func (r *encounterRepository) CreateEncounter(encounter *models.Encounter) (*models.Encounter, error) {
+	if err := r.db.Create(encounter).Error; err != nil {
+		return nil, err
+	}
+	return encounter, nil
+}
+
+func (r *encounterRepository) GetEncounterById(patientID, id uint) (*models.Encounter, error) {
+	var encounter models.Encounter
+
+	err := r.db.Where("patient_id = ?", patientID).First(&encounter, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &encounter, nil
+}
+
+func (r *encounterRepository) GetEncounterByAppointmentId(appointmentID uint) (*models.Encounter, error) {
+	var encounter models.Encounter
+
+	err := r.db.Where("appointment_id = ?", appointmentID).First(&encounter).Error
+	if err != nil {
+		return nil, err
+	}
+	return &encounter, nil
+}
+
+// ListEncounters returns the patient's encounters, most recent visit first.
+func (r *encounterRepository) ListEncounters(patientID uint) ([]models.Encounter, error) {
+	var encounters []models.Encounter
+
+	err := r.db.Where("patient_id = ?", patientID).Order("check_in_at DESC").Order("id DESC").Find(&encounters).Error
+	if err != nil {
+		return nil, err
+	}
+	return encounters, nil
+}
+
+// CheckOutEncounter sets the check-out time of an encounter that is still
+// open, returning gorm.ErrRecordNotFound if there is none.
+func (r *encounterRepository) CheckOutEncounter(patientID, id uint, at time.Time) (*models.Encounter, error) {
+	var encounter models.Encounter
+
+	result := r.db.Model(&encounter).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ? AND check_out_at IS NULL", patientID, id).
+		Update("check_out_at", at)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &encounter, nil
+}
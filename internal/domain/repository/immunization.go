@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type immunizationRepository struct {
+	db *gorm.DB
+}
+
+func NewImmunizationRepository(db *gorm.DB) *immunizationRepository {
+	return &immunizationRepository{
+		db: db,
+	}
+}
+
+func (r *immunizationRepository) CreateImmunization(immunization *models.Immunization) (*models.Immunization, error) {
+	if err := r.db.Create(immunization).Error; err != nil {
+		return nil, err
+	}
+	return immunization, nil
+}
+
+// ListImmunizations returns the patient's immunizations in the order they
+// were given.
+func (r *immunizationRepository) ListImmunizations(patientID uint) ([]models.Immunization, error) {
+	var immunizations []models.Immunization
+
+	err := r.db.Where("patient_id = ?", patientID).
+		Order("administered_on").Order("dose_number").Order("id").Find(&immunizations).Error
+	if err != nil {
+		return nil, err
+	}
+	return immunizations, nil
+}
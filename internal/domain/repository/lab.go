@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LabOrderFilter narrows a patient's lab orders. Zero fields are ignored.
+type LabOrderFilter struct {
+	Status      models.LabOrderStatus
+	EncounterID uint
+}
+
+type labRepository struct {
+	db *gorm.DB
+}
+
+func NewLabRepository(db *gorm.DB) *labRepository {
+	return &labRepository{
+		db: db,
+	}
+}
+
+func (r *labRepository) CreateLabOrder(order *models.LabOrder) (*models.LabOrder, error) {
+	if err := r.db.Create(order).Error; err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *labRepository) GetLabOrderById(patientID, id uint) (*models.LabOrder, error) {
+	var order models.LabOrder
+
+	err := r.db.Preload("Results", orderResults).Where("patient_id = ?", patientID).First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListLabOrders returns the patient's orders matching filter with their
+// results, newest first.
+func (r *labRepository) ListLabOrders(patientID uint, filter LabOrderFilter) ([]models.LabOrder, error) {
+	var orders []models.LabOrder
+
+	query := r.db.Preload("Results", orderResults).Where("patient_id = ?", patientID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EncounterID != 0 {
+		query = query.Where("encounter_id = ?", filter.EncounterID)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// UpdateLabOrderById applies updates only while the order is in one of
+// fromStatuses, so concurrent transitions cannot both succeed. It returns
+// gorm.ErrRecordNotFound when no row matched.
+func (r *labRepository) UpdateLabOrderById(patientID, id uint, fromStatuses []models.LabOrderStatus, updates map[string]interface{}) (*models.LabOrder, error) {
+	var order models.LabOrder
+
+	result := r.db.Model(&order).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ? AND status IN ?", patientID, id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &order, nil
+}
+
+// RecordLabResults marks a collected order resulted, applying updates, and
+// stores its results and, when alert is not nil, the worklist item they
+// raised. Everything is written or nothing is; gorm.ErrRecordNotFound means
+// the order was no longer awaiting results.
+func (r *labRepository) RecordLabResults(patientID, id uint, results []models.LabResult, updates map[string]interface{}, alert *models.WorklistItem) (*models.LabOrder, error) {
+	var order models.LabOrder
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates["status"] = models.LabResulted
+		result := tx.Model(&order).Clauses(clause.Returning{}).
+			Where("patient_id = ? AND id = ? AND status = ?", patientID, id, models.LabCollected).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for i := range results {
+			results[i].LabOrderID = order.ID
+		}
+		if err := tx.Create(&results).Error; err != nil {
+			return err
+		}
+		order.Results = results
+
+		if alert == nil {
+			return nil
+		}
+		alert.SourceID = order.ID
+		return tx.Create(alert).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// orderResults keeps results in the order the lab reported them.
+func orderResults(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NoteFilter narrows a patient's notes to one encounter or to the addenda of
+// one note. Zero fields are ignored.
+type NoteFilter struct {
+	EncounterID uint
+	AddendumTo  uint
+}
+
+type noteRepository struct {
+	db *gorm.DB
+}
+
+func NewNoteRepository(db *gorm.DB) *noteRepository {
+	return &noteRepository{
+		db: db,
+	}
+}
+
+func (r *noteRepository) CreateNote(note *models.ClinicalNote) (*models.ClinicalNote, error) {
+	if err := r.db.Create(note).Error; err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (r *noteRepository) GetNoteById(patientID, id uint) (*models.ClinicalNote, error) {
+	var note models.ClinicalNote
+
+	err := r.db.Where("patient_id = ?", patientID).First(&note, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// ListNotes returns the patient's notes matching filter in the order they
+// were written.
+func (r *noteRepository) ListNotes(patientID uint, filter NoteFilter) ([]models.ClinicalNote, error) {
+	var notes []models.ClinicalNote
+
+	query := r.db.Where("patient_id = ?", patientID)
+	if filter.EncounterID != 0 {
+		query = query.Where("encounter_id = ?", filter.EncounterID)
+	}
+	if filter.AddendumTo != 0 {
+		query = query.Where("addendum_to = ?", filter.AddendumTo)
+	}
+
+	if err := query.Order("created_at").Order("id").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// UpdateDraftNoteById applies updates only while the note is a draft, so a
+// note signed concurrently is never changed. It returns
+// gorm.ErrRecordNotFound when no draft matched.
+func (r *noteRepository) UpdateDraftNoteById(patientID, id uint, updates map[string]interface{}) (*models.ClinicalNote, error) {
+	var note models.ClinicalNote
+
+	result := r.db.Model(&note).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ? AND status = ?", patientID, id, models.NoteDraft).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &note, nil
+}
@@ -1,11 +1,62 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// ErrPatientEmailTaken is returned when a write would give two patients that
+// are not archived the same email.
+var ErrPatientEmailTaken = errors.New("patient email already in use")
+
+type PatientFilter struct {
+	NamePrefix  string
+	DOB         *time.Time
+	PhoneNumber string
+	Email       string
+	Gender      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      string
+	SortDesc    bool
+	Archived    bool
+	Limit       int
+	Offset      int
+}
+
+const (
+	fuzzyMatchThreshold = 0.3
+	phoneticMatchScore  = 0.5
+)
+
+type PatientMatch struct {
+	models.Patient `gorm:"embedded"`
+	Score          float64
+}
+
+// patientOwnedModels lists every table with a patient_id column. Merging
+// patients re-points these rows from the merged record to the surviving one.
+var patientOwnedModels = []interface{}{
+	&models.Appointment{},
+	&models.Condition{},
+	&models.Allergy{},
+	&models.Prescription{},
+	&models.Vital{},
+	&models.WorklistItem{},
+	&models.Encounter{},
+	&models.ClinicalNote{},
+	&models.Immunization{},
+	// results reference their order, so purge them first
+	&models.LabResult{},
+	&models.LabOrder{},
+}
+
 type patientRepository struct {
 	db *gorm.DB
 }
@@ -15,21 +66,57 @@ func NewPatientRepository(db *gorm.DB) *patientRepository {
 		db: db,
 	}
 }
-func (r *patientRepository) CreatePatient(patient *models.Patient) (*models.Patient, error) {
-	result := r.db.Create(patient)
-
-	if result.Error != nil {
-		return nil, result.Error
+func (r *patientRepository) CreatePatient(patient *models.Patient, changedBy string) (*models.Patient, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(patient).Error; err != nil {
+			return err
+		}
+		return recordPatientVersion(tx, nil, patient, models.PatientChangeCreate, changedBy)
+	})
+	if err != nil {
+		if isConstraintViolation(err, models.PatientEmailIndex) {
+			return nil, ErrPatientEmailTaken
+		}
+		return nil, err
 	}
 
 	return patient, nil
 }
 
-func (r *patientRepository) UpdatePatientById(id uint, updates map[string]interface{}) (*models.Patient, error) {
+// PatchPatientById locks the patient row and asks apply for the column updates
+// to make against the locked state, so conditional updates cannot race. No
+// update is written when apply returns no changes; otherwise the patient's
+// version is bumped.
+func (r *patientRepository) PatchPatientById(id uint, apply func(current *models.Patient) (map[string]interface{}, error), changedBy string) (*models.Patient, error) {
 	var patient models.Patient
 
-	err := r.db.Model(&patient).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Patient
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, id).Error; err != nil {
+			return err
+		}
+
+		current := before
+		updates, err := apply(&current)
+		if err != nil {
+			return err
+		}
+		if len(updates) == 0 {
+			patient = before
+			return nil
+		}
+
+		updates["version"] = gorm.Expr("version + 1")
+		err = tx.Model(&patient).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+		return recordPatientVersion(tx, &before, &patient, models.PatientChangeUpdate, changedBy)
+	})
 	if err != nil {
+		if isConstraintViolation(err, models.PatientEmailIndex) {
+			return nil, ErrPatientEmailTaken
+		}
 		return nil, err
 	}
 	return &patient, nil
@@ -46,12 +133,425 @@ func (r *patientRepository) GetPatientById(id uint) (*models.Patient, error) {
 	return &patient, nil
 }
 
-func (r *patientRepository) DeletePatientById(id uint) error {
+// ArchivePatientById soft deletes the patient, hiding it from normal reads
+// while keeping the record and its history.
+func (r *patientRepository) ArchivePatientById(id uint, changedBy string) (*models.Patient, error) {
+	var patient models.Patient
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Patient
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")}
+		err := tx.Model(&patient).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+		return recordPatientVersion(tx, &before, &patient, models.PatientChangeArchive, changedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &patient, nil
+}
+
+// RestorePatientById brings an archived patient back into normal reads.
+func (r *patientRepository) RestorePatientById(id uint, changedBy string) (*models.Patient, error) {
+	var patient models.Patient
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Patient
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL").First(&before, id).Error
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}
+		err = tx.Unscoped().Model(&patient).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+		return recordPatientVersion(tx, &before, &patient, models.PatientChangeRestore, changedBy)
+	})
+	if err != nil {
+		if isConstraintViolation(err, models.PatientEmailIndex) {
+			return nil, ErrPatientEmailTaken
+		}
+		return nil, err
+	}
+	return &patient, nil
+}
+
+func (r *patientRepository) GetArchivedPatientById(id uint) (*models.Patient, error) {
 	var patient models.Patient
 
-	err := r.db.Delete(&patient, id).Error
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&patient, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &patient, nil
+}
+
+// PurgePatientById permanently removes an archived patient together with its
+// owned rows, history and the tombstones of records merged into it. Audit
+// events are kept. Signed clinical notes are protected by a trigger that lets
+// their deletion through only because the transaction sets app.patient_purge.
+func (r *patientRepository) PurgePatientById(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var patient models.Patient
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL").First(&patient, id).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("SET LOCAL app.patient_purge = 'on'").Error; err != nil {
+			return err
+		}
+
+		for _, model := range patientOwnedModels {
+			if err := tx.Unscoped().Where("patient_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("patient_id = ?", id).Delete(&models.PatientVersion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_id = ?", id).Delete(&models.PatientTombstone{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&patient).Error
+	})
+}
+
+// patientActivity lists the timestamps that count as activity on a patient's
+// chart. A row in any of them at or after the cutoff keeps the patient active.
+var patientActivity = []struct{ table, column string }{
+	{"appointments", "start_time"},
+	{"encounters", "check_in_at"},
+	{"encounters", "updated_at"},
+	{"vitals", "recorded_at"},
+	{"clinical_notes", "updated_at"},
+	{"prescriptions", "updated_at"},
+	{"lab_orders", "updated_at"},
+	{"immunizations", "administered_on"},
+	{"immunizations", "created_at"},
+	{"conditions", "updated_at"},
+	{"allergies", "updated_at"},
+}
+
+// ListInactivePatientIDs returns patients that have not been anonymised and
+// have had neither an update nor any clinical activity since cutoff.
+func (r *patientRepository) ListInactivePatientIDs(cutoff time.Time) ([]uint, error) {
+	var ids []uint
+
+	query := r.db.Model(&models.Patient{}).
+		Where("anonymised_at IS NULL AND updated_at < ?", cutoff)
+	for _, activity := range patientActivity {
+		query = query.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.patient_id = patients.id AND %[1]s.%[2]s >= ?)",
+			activity.table, activity.column), cutoff)
+	}
+	if err := query.Order("id").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListArchivedPatientIDs returns patients archived before archivedBefore.
+func (r *patientRepository) ListArchivedPatientIDs(archivedBefore time.Time) ([]uint, error) {
+	var ids []uint
+
+	err := r.db.Unscoped().Model(&models.Patient{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", archivedBefore).
+		Order("id").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// AnonymisePatientById strips identifying attributes from the patient while
+// keeping clinical data. The version history holds copies of the identifying
+// values, so it is replaced by a single snapshot of the anonymised record.
+func (r *patientRepository) AnonymisePatientById(id uint, changedBy string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Patient
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, id).Error; err != nil {
+			return err
+		}
+
+		var patient models.Patient
+		updates := map[string]interface{}{
+			"first_name":    "Anonymised",
+			"last_name":     "Patient",
+			"email":         nil,
+			"phone_number":  "",
+			"address":       "",
+			"dob":           time.Date(before.DOB.Year(), time.January, 1, 0, 0, 0, 0, time.UTC),
+			"anonymised_at": time.Now(),
+			"version":       gorm.Expr("version + 1"),
+		}
+		err := tx.Model(&patient).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("patient_id = ?", id).Delete(&models.PatientVersion{}).Error; err != nil {
+			return err
+		}
+		return recordPatientVersion(tx, nil, &patient, models.PatientChangeAnonymise, changedBy)
+	})
+}
+
+func (r *patientRepository) ListPatients(filter PatientFilter) ([]models.Patient, int64, error) {
+	var patients []models.Patient
+	var total int64
+
+	query := applyPatientFilter(r.db.Model(&models.Patient{}), filter)
+	if filter.NamePrefix != "" {
+		prefix := escapeLike(filter.NamePrefix) + "%"
+		query = query.Where("first_name ILIKE ? OR last_name ILIKE ?", prefix, prefix)
+	}
+	if filter.DOB != nil {
+		query = query.Where("dob = ?", *filter.DOB)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// SortBy is whitelisted by the service before it reaches the query
+	order := clause.OrderByColumn{Column: clause.Column{Name: filter.SortBy}, Desc: filter.SortDesc}
+	err := query.Order(order).Order("id").Limit(filter.Limit).Offset(filter.Offset).Find(&patients).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return patients, total, nil
+}
+
+// FuzzySearchPatients ranks patients by how closely their name resembles term,
+// combining pg_trgm similarity with a Double Metaphone match. Results below
+// fuzzyMatchThreshold are dropped. filter.DOB is used to break ties instead of
+// filtering, so a misspelt name with the right birth date still ranks first.
+func (r *patientRepository) FuzzySearchPatients(term string, filter PatientFilter) ([]PatientMatch, int64, error) {
+	var matches []PatientMatch
+	var total int64
+
+	score := `GREATEST(
+		similarity(first_name || ' ' || last_name, @term),
+		similarity(first_name, @term),
+		similarity(last_name, @term),
+		CASE WHEN dmetaphone(last_name) = dmetaphone(@term) OR dmetaphone(first_name) = dmetaphone(@term)
+			THEN @phonetic ELSE 0 END)`
+	args := map[string]interface{}{"term": term, "phonetic": phoneticMatchScore, "threshold": fuzzyMatchThreshold}
+
+	query := applyPatientFilter(r.db.Model(&models.Patient{}), filter).Where(score+" >= @threshold", args)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Select("patients.*, "+score+" AS score", args).Order("score DESC")
+	if filter.DOB != nil {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{SQL: "dob = ? DESC", Vars: []interface{}{*filter.DOB}}})
+	}
+	err := query.Order("dob").Order("id").Limit(filter.Limit).Offset(filter.Offset).Scan(&matches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+// FindDuplicatePatients returns existing patients that probably describe the
+// same person: same name and date of birth, same phone number or same email.
+// Archived patients are included, since restoring one is usually better than
+// registering the person again.
+func (r *patientRepository) FindDuplicatePatients(patient *models.Patient) ([]models.Patient, error) {
+	var patients []models.Patient
+
+	query := r.db.Unscoped().Where("LOWER(first_name) = LOWER(?) AND LOWER(last_name) = LOWER(?) AND dob = ?",
+		patient.FirstName, patient.LastName, patient.DOB).
+		Or("phone_number = ?", patient.PhoneNumber)
+	if patient.Email != nil {
+		query = query.Or("LOWER(email) = LOWER(?)", *patient.Email)
+	}
+
+	err := query.Order("id").Find(&patients).Error
+	if err != nil {
+		return nil, err
+	}
+	return patients, nil
+}
+
+// MergePatients folds source into target: related rows are re-pointed, blank
+// fields on target are filled from source, source is removed and a tombstone
+// is left behind for its ID.
+func (r *patientRepository) MergePatients(sourceID, targetID uint, changedBy string) (*models.Patient, error) {
+	var target models.Patient
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// both rows are locked in id order so that merges running in opposite
+		// directions queue up instead of deadlocking
+		var patients []models.Patient
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{sourceID, targetID}).Order("id").Find(&patients).Error
+		if err != nil {
+			return err
+		}
+		if len(patients) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var source models.Patient
+		for _, p := range patients {
+			if p.ID == sourceID {
+				source = p
+			} else {
+				target = p
+			}
+		}
+
+		for _, model := range patientOwnedModels {
+			if err := tx.Unscoped().Model(model).Where("patient_id = ?", sourceID).Update("patient_id", targetID).Error; err != nil {
+				return err
+			}
+		}
+
+		// the surviving record carries the merged data, so the source row is
+		// removed outright rather than archived
+		if err := tx.Unscoped().Delete(&source).Error; err != nil {
+			return err
+		}
+
+		before := target
+		updates := map[string]interface{}{"version": gorm.Expr("version + 1")}
+		if target.Email == nil && source.Email != nil {
+			updates["email"] = *source.Email
+		}
+		if target.MedicalHistory == "" && source.MedicalHistory != "" {
+			updates["medical_history"] = source.MedicalHistory
+		}
+		if err := tx.Model(&target).Clauses(clause.Returning{}).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := recordPatientVersion(tx, &before, &target, models.PatientChangeMerge, changedBy); err != nil {
+			return err
+		}
+
+		// keep older tombstones pointing straight at the surviving record
+		err = tx.Model(&models.PatientTombstone{}).Where("target_id = ?", sourceID).Update("target_id", targetID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.PatientTombstone{MergedID: sourceID, TargetID: targetID}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (r *patientRepository) GetPatientTombstone(id uint) (*models.PatientTombstone, error) {
+	var tombstone models.PatientTombstone
+
+	err := r.db.First(&tombstone, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tombstone, nil
+}
+
+func (r *patientRepository) ListPatientVersions(patientID uint) ([]models.PatientVersion, error) {
+	var versions []models.PatientVersion
+
+	err := r.db.Where("patient_id = ?", patientID).Order("version").Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetPatientVersionAsOf returns the latest snapshot taken at or before asOf.
+func (r *patientRepository) GetPatientVersionAsOf(patientID uint, asOf time.Time) (*models.PatientVersion, error) {
+	var version models.PatientVersion
+
+	err := r.db.Where("patient_id = ? AND created_at <= ?", patientID, asOf).
+		Order("version DESC").First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// recordPatientVersion appends a snapshot of after to the patient's history.
+// before is nil for newly created patients. Patients that predate history
+// tracking get a baseline snapshot of before first, dated at its last update.
+func recordPatientVersion(tx *gorm.DB, before, after *models.Patient, changeType models.PatientChangeType, changedBy string) error {
+	var latest int
+	err := tx.Model(&models.PatientVersion{}).Where("patient_id = ?", after.ID).
+		Select("COALESCE(MAX(version), 0)").Scan(&latest).Error
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if latest == 0 && before != nil {
+		baseline := &models.PatientVersion{
+			PatientID:  before.ID,
+			Version:    1,
+			ChangeType: models.PatientChangeBaseline,
+			Changes:    (*models.Patient)(nil).Diff(before),
+			Snapshot:   *before,
+			CreatedAt:  before.UpdatedAt,
+		}
+		if err := tx.Create(baseline).Error; err != nil {
+			return err
+		}
+		latest = 1
+	}
+
+	changes := before.Diff(after)
+	if changeType == models.PatientChangeUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	return tx.Create(&models.PatientVersion{
+		PatientID:  after.ID,
+		Version:    latest + 1,
+		ChangeType: changeType,
+		ChangedBy:  changedBy,
+		Changes:    changes,
+		Snapshot:   *after,
+		CreatedAt:  after.UpdatedAt,
+	}).Error
+}
+
+func applyPatientFilter(query *gorm.DB, filter PatientFilter) *gorm.DB {
+	if filter.Archived {
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+	if filter.PhoneNumber != "" {
+		query = query.Where("phone_number = ?", filter.PhoneNumber)
+	}
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", filter.Email)
+	}
+	if filter.Gender != "" {
+		query = query.Where("gender = ?", filter.Gender)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type prescriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewPrescriptionRepository(db *gorm.DB) *prescriptionRepository {
+	return &prescriptionRepository{
+		db: db,
+	}
+}
+
+func (r *prescriptionRepository) CreatePrescription(prescription *models.Prescription) (*models.Prescription, error) {
+	if err := r.db.Create(prescription).Error; err != nil {
+		return nil, err
+	}
+	return prescription, nil
+}
+
+func (r *prescriptionRepository) GetPrescriptionById(patientID, id uint) (*models.Prescription, error) {
+	var prescription models.Prescription
+
+	err := r.db.Where("patient_id = ?", patientID).First(&prescription, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &prescription, nil
+}
+
+// ListPrescriptions returns the patient's prescriptions, newest first. Nil
+// statuses return every prescription.
+func (r *prescriptionRepository) ListPrescriptions(patientID uint, statuses []models.PrescriptionStatus) ([]models.Prescription, error) {
+	var prescriptions []models.Prescription
+
+	query := r.db.Where("patient_id = ?", patientID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").Find(&prescriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return prescriptions, nil
+}
+
+// UpdatePrescriptionById applies updates only while the prescription is in
+// one of fromStatuses, so a concurrent sign cannot interleave with an edit.
+// It returns gorm.ErrRecordNotFound when no row matched.
+func (r *prescriptionRepository) UpdatePrescriptionById(patientID, id uint, fromStatuses []models.PrescriptionStatus, updates map[string]interface{}) (*models.Prescription, error) {
+	var prescription models.Prescription
+
+	result := r.db.Model(&prescription).Clauses(clause.Returning{}).
+		Where("patient_id = ? AND id = ? AND status IN ?", patientID, id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &prescription, nil
+}
+
+func (r *prescriptionRepository) DeleteDraftPrescriptionById(patientID, id uint) error {
+	result := r.db.Where("patient_id = ? AND status = ?", patientID, models.PrescriptionDraft).Delete(&models.Prescription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -1,19 +1,146 @@
 package repository
 
-import "github.com/palashbhasme/healthcare-portal/internal/domain/models"
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+)
 
 type UserRepository interface {
 	CreateUser(user *models.User) (*models.User, error)
 	GetUserByID(id uint) (*models.User, error)
 	UpdateUserById(id uint, updates map[string]interface{}) (*models.User, error)
+	PatchUserById(id uint, apply func(current *models.User) (map[string]interface{}, error)) (*models.User, error)
 	DeleteUserById(id uint) error
 	GetUserByName(username string) (*models.User, error)
 	CheckUserExists(username string) (bool, error)
 }
 
 type PatientRepository interface {
-	CreatePatient(patient *models.Patient) (*models.Patient, error)
+	CreatePatient(patient *models.Patient, changedBy string) (*models.Patient, error)
 	GetPatientById(id uint) (*models.Patient, error)
-	UpdatePatientById(id uint, updates map[string]interface{}) (*models.Patient, error)
-	DeletePatientById(id uint) error
+	PatchPatientById(id uint, apply func(current *models.Patient) (map[string]interface{}, error), changedBy string) (*models.Patient, error)
+	ArchivePatientById(id uint, changedBy string) (*models.Patient, error)
+	RestorePatientById(id uint, changedBy string) (*models.Patient, error)
+	GetArchivedPatientById(id uint) (*models.Patient, error)
+	PurgePatientById(id uint) error
+	ListInactivePatientIDs(cutoff time.Time) ([]uint, error)
+	ListArchivedPatientIDs(archivedBefore time.Time) ([]uint, error)
+	AnonymisePatientById(id uint, changedBy string) error
+	ListPatients(filter PatientFilter) ([]models.Patient, int64, error)
+	FuzzySearchPatients(term string, filter PatientFilter) ([]PatientMatch, int64, error)
+	FindDuplicatePatients(patient *models.Patient) ([]models.Patient, error)
+	MergePatients(sourceID, targetID uint, changedBy string) (*models.Patient, error)
+	GetPatientTombstone(id uint) (*models.PatientTombstone, error)
+	ListPatientVersions(patientID uint) ([]models.PatientVersion, error)
+	GetPatientVersionAsOf(patientID uint, asOf time.Time) (*models.PatientVersion, error)
+}
+
+type AppointmentRepository interface {
+	CreateAppointment(appointment *models.Appointment) (*models.Appointment, error)
+	GetAppointmentById(id uint) (*models.Appointment, error)
+	UpdateAppointmentById(id uint, updates map[string]interface{}) (*models.Appointment, error)
+	ListAppointments(filter AppointmentFilter) ([]models.Appointment, error)
+	HasOverlappingAppointment(doctorID uint, start, end time.Time, excludeID uint) (bool, error)
+}
+
+type AvailabilityRepository interface {
+	ReplaceWorkingHours(doctorID uint, hours []models.WorkingHours) ([]models.WorkingHours, error)
+	GetWorkingHours(doctorID uint) ([]models.WorkingHours, error)
+	CreateException(exception *models.AvailabilityException) (*models.AvailabilityException, error)
+	ListExceptions(doctorID uint, from, to time.Time) ([]models.AvailabilityException, error)
+	DeleteException(doctorID, id uint) error
+}
+
+type AuditRepository interface {
+	CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error)
+	ListAuditEvents(filter AuditFilter) ([]models.AuditEvent, int64, error)
+	WalkAuditEvents(batchSize int, fn func(events []models.AuditEvent) error) error
+	GetLatestAuditCheckpoint() (*models.AuditCheckpoint, error)
+	CountAuditEventsBefore(cutoff time.Time) (int64, error)
+	PurgeAuditEventsBefore(cutoff time.Time) (int64, error)
+}
+
+type RetentionRepository interface {
+	CreateRetentionRun(run *models.RetentionRun) (*models.RetentionRun, error)
+	ListRetentionRuns(limit int) ([]models.RetentionRun, error)
+}
+
+type ConditionRepository interface {
+	CreateCondition(condition *models.Condition) (*models.Condition, error)
+	GetConditionById(patientID, id uint) (*models.Condition, error)
+	ListConditions(patientID uint, status models.ConditionStatus) ([]models.Condition, error)
+	UpdateConditionById(patientID, id uint, updates map[string]interface{}) (*models.Condition, error)
+	DeleteConditionById(patientID, id uint) error
+}
+
+type CodeRepository interface {
+	ReplaceICD10Codes(codes []models.ICD10Code) error
+	CountICD10Codes() (int64, error)
+	CountImportedICD10Codes() (int64, error)
+	GetICD10Code(code string) (*models.ICD10Code, error)
+	SearchICD10Codes(term string, limit int) ([]models.ICD10Code, error)
+}
+
+type AllergyRepository interface {
+	CreateAllergy(allergy *models.Allergy) (*models.Allergy, error)
+	GetAllergyById(patientID, id uint) (*models.Allergy, error)
+	ListAllergies(patientID uint) ([]models.Allergy, error)
+	UpdateAllergyById(patientID, id uint, updates map[string]interface{}) (*models.Allergy, error)
+	DeleteAllergyById(patientID, id uint) error
+}
+
+type PrescriptionRepository interface {
+	CreatePrescription(prescription *models.Prescription) (*models.Prescription, error)
+	GetPrescriptionById(patientID, id uint) (*models.Prescription, error)
+	ListPrescriptions(patientID uint, statuses []models.PrescriptionStatus) ([]models.Prescription, error)
+	UpdatePrescriptionById(patientID, id uint, fromStatuses []models.PrescriptionStatus, updates map[string]interface{}) (*models.Prescription, error)
+	DeleteDraftPrescriptionById(patientID, id uint) error
+}
+
+type VitalRepository interface {
+	CreateVital(vital *models.Vital, alert *models.WorklistItem) (*models.Vital, error)
+	ListVitals(patientID uint, filter VitalFilter) ([]models.Vital, error)
+}
+
+type VitalRuleRepository interface {
+	CreateVitalRule(rule *models.VitalRule) (*models.VitalRule, error)
+	CreateVitalRules(rules []models.VitalRule) error
+	CountVitalRules() (int64, error)
+	ListVitalRules(measurement string) ([]models.VitalRule, error)
+	UpdateVitalRuleById(id uint, updates map[string]interface{}) (*models.VitalRule, error)
+	DeleteVitalRuleById(id uint) error
+}
+
+type WorklistRepository interface {
+	ListWorklistItems(doctorID uint, status models.WorklistItemStatus) ([]models.WorklistItem, error)
+	AcknowledgeWorklistItem(doctorID, id uint, at time.Time) (*models.WorklistItem, error)
+}
+
+type EncounterRepository interface {
+	CreateEncounter(encounter *models.Encounter) (*models.Encounter, error)
+	GetEncounterById(patientID, id uint) (*models.Encounter, error)
+	GetEncounterByAppointmentId(appointmentID uint) (*models.Encounter, error)
+	ListEncounters(patientID uint) ([]models.Encounter, error)
+	CheckOutEncounter(patientID, id uint, at time.Time) (*models.Encounter, error)
+}
+
+type NoteRepository interface {
+	CreateNote(note *models.ClinicalNote) (*models.ClinicalNote, error)
+	GetNoteById(patientID, id uint) (*models.ClinicalNote, error)
+	ListNotes(patientID uint, filter NoteFilter) ([]models.ClinicalNote, error)
+	UpdateDraftNoteById(patientID, id uint, updates map[string]interface{}) (*models.ClinicalNote, error)
+}
+
+type LabRepository interface {
+	CreateLabOrder(order *models.LabOrder) (*models.LabOrder, error)
+	GetLabOrderById(patientID, id uint) (*models.LabOrder, error)
+	ListLabOrders(patientID uint, filter LabOrderFilter) ([]models.LabOrder, error)
+	UpdateLabOrderById(patientID, id uint, fromStatuses []models.LabOrderStatus, updates map[string]interface{}) (*models.LabOrder, error)
+	RecordLabResults(patientID, id uint, results []models.LabResult, updates map[string]interface{}, alert *models.WorklistItem) (*models.LabOrder, error)
+}
+
+type ImmunizationRepository interface {
+	CreateImmunization(immunization *models.Immunization) (*models.Immunization, error)
+	ListImmunizations(patientID uint) ([]models.Immunization, error)
 }
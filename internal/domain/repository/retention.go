@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) *retentionRepository {
+	return &retentionRepository{
+		db: db,
+	}
+}
+
+func (r *retentionRepository) CreateRetentionRun(run *models.RetentionRun) (*models.RetentionRun, error) {
+	if err := r.db.Create(run).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (r *retentionRepository) ListRetentionRuns(limit int) ([]models.RetentionRun, error) {
+	var runs []models.RetentionRun
+
+	err := r.db.Order("started_at DESC").Order("id DESC").Limit(limit).Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -36,6 +36,33 @@ func (r *userRepository) UpdateUserById(id uint, updates map[string]interface{})
 	return &user, nil
 }
 
+// PatchUserById locks the user row and asks apply for the column updates to
+// make against the locked state.
+func (r *userRepository) PatchUserById(id uint, apply func(current *models.User) (map[string]interface{}, error)) (*models.User, error) {
+	var user models.User
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, id).Error; err != nil {
+			return err
+		}
+
+		updates, err := apply(&current)
+		if err != nil {
+			return err
+		}
+		if len(updates) == 0 {
+			user = current
+			return nil
+		}
+		return tx.Model(&user).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) GetUserByID(id uint) (*models.User, error) {
 	var user models.User
 
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type VitalFilter struct {
+	Type models.VitalType
+	From *time.Time
+	To   *time.Time
+}
+
+// vitalTypeColumns maps each vital type to the column a reading must have
+// set to belong to that series.
+var vitalTypeColumns = map[models.VitalType]string{
+	models.VitalBloodPressure: "systolic_bp",
+	models.VitalHeartRate:     "heart_rate",
+	models.VitalTemperature:   "temperature_c",
+	models.VitalSpO2:          "spo2",
+	models.VitalWeight:        "weight_kg",
+	models.VitalHeight:        "height_cm",
+	models.VitalBMI:           "weight_kg",
+}
+
+type vitalRepository struct {
+	db *gorm.DB
+}
+
+func NewVitalRepository(db *gorm.DB) *vitalRepository {
+	return &vitalRepository{
+		db: db,
+	}
+}
+
+// CreateVital stores vital and, when alert is not nil, the worklist item it
+// raised, linking the item to the new reading. Both are written or neither.
+func (r *vitalRepository) CreateVital(vital *models.Vital, alert *models.WorklistItem) (*models.Vital, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(vital).Error; err != nil {
+			return err
+		}
+		if alert == nil {
+			return nil
+		}
+		alert.SourceID = vital.ID
+		return tx.Create(alert).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vital, nil
+}
+
+// ListVitals returns the patient's readings matching filter, oldest first.
+func (r *vitalRepository) ListVitals(patientID uint, filter VitalFilter) ([]models.Vital, error) {
+	var vitals []models.Vital
+
+	query := r.db.Where("patient_id = ?", patientID)
+	if column, ok := vitalTypeColumns[filter.Type]; ok {
+		query = query.Where(column + " IS NOT NULL")
+	}
+	if filter.From != nil {
+		query = query.Where("recorded_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("recorded_at < ?", *filter.To)
+	}
+
+	if err := query.Order("recorded_at").Order("id").Find(&vitals).Error; err != nil {
+		return nil, err
+	}
+	return vitals, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type vitalRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewVitalRuleRepository(db *gorm.DB) *vitalRuleRepository {
+	return &vitalRuleRepository{
+		db: db,
+	}
+}
+
+func (r *vitalRuleRepository) CreateVitalRule(rule *models.VitalRule) (*models.VitalRule, error) {
+	if err := r.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *vitalRuleRepository) CreateVitalRules(rules []models.VitalRule) error {
+	return r.db.Create(&rules).Error
+}
+
+func (r *vitalRuleRepository) CountVitalRules() (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.VitalRule{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListVitalRules returns the rules for measurement, or every rule when it is
+// empty, grouped by measurement and ordered by age band.
+func (r *vitalRuleRepository) ListVitalRules(measurement string) ([]models.VitalRule, error) {
+	var rules []models.VitalRule
+
+	query := r.db.Model(&models.VitalRule{})
+	if measurement != "" {
+		query = query.Where("measurement = ?", measurement)
+	}
+	if err := query.Order("measurement").Order("min_age_years").Order("id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *vitalRuleRepository) UpdateVitalRuleById(id uint, updates map[string]interface{}) (*models.VitalRule, error) {
+	var rule models.VitalRule
+
+	result := r.db.Model(&rule).Clauses(clause.Returning{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &rule, nil
+}
+
+func (r *vitalRuleRepository) DeleteVitalRuleById(id uint) error {
+	result := r.db.Delete(&models.VitalRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type worklistRepository struct {
+	db *gorm.DB
+}
+
+func NewWorklistRepository(db *gorm.DB) *worklistRepository {
+	return &worklistRepository{
+		db: db,
+	}
+}
+
+// ListWorklistItems returns the doctor's items with the given status, or all
+// of them when status is empty, newest first.
+func (r *worklistRepository) ListWorklistItems(doctorID uint, status models.WorklistItemStatus) ([]models.WorklistItem, error) {
+	var items []models.WorklistItem
+
+	query := r.db.Where("doctor_id = ?", doctorID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// AcknowledgeWorklistItem marks an open item as acknowledged. Acknowledging
+// an item twice leaves the first acknowledgement in place.
+func (r *worklistRepository) AcknowledgeWorklistItem(doctorID, id uint, at time.Time) (*models.WorklistItem, error) {
+	var item models.WorklistItem
+
+	result := r.db.Model(&item).Clauses(clause.Returning{}).
+		Where("doctor_id = ? AND id = ? AND status = ?", doctorID, id, models.WorklistOpen).
+		Updates(map[string]interface{}{"status": models.WorklistAcknowledged, "acknowledged_at": at})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return &item, nil
+	}
+
+	if err := r.db.Where("doctor_id = ?", doctorID).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
@@ -0,0 +1,131 @@
+package allergy_service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type AllergyService struct {
+	allergyRepo repository.AllergyRepository
+	patientRepo repository.PatientRepository
+}
+
+func NewAllergyService(allergyRepo repository.AllergyRepository, patientRepo repository.PatientRepository) *AllergyService {
+	return &AllergyService{
+		allergyRepo: allergyRepo,
+		patientRepo: patientRepo,
+	}
+}
+
+func (s *AllergyService) CreateAllergy(patientIdStr string, allergyRequest *request.AllergyRequest, role any, userID string) (*response.AllergyResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "update_allergy")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	allergy, err := s.allergyRepo.CreateAllergy(mapper.AllergyToModel(patientID, allergyRequest, userID))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AllergyToResponse(allergy), nil
+}
+
+func (s *AllergyService) ListAllergies(patientIdStr string, role any) ([]*response.AllergyResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_allergy")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	allergies, err := s.allergyRepo.ListAllergies(patientID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AllergiesToResponse(allergies), nil
+}
+
+func (s *AllergyService) GetAllergyById(patientIdStr, allergyIdStr string, role any) (*response.AllergyResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_allergy")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	allergyID, err := parseAllergyID(allergyIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	allergy, err := s.allergyRepo.GetAllergyById(patientID, allergyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("allergy not found")
+		}
+		return nil, err
+	}
+	return mapper.AllergyToResponse(allergy), nil
+}
+
+func (s *AllergyService) UpdateAllergyById(patientIdStr, allergyIdStr string, updateRequest *request.AllergyUpdateRequest, role any) (*response.AllergyResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "update_allergy")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	allergyID, err := parseAllergyID(allergyIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := mapper.AllergyUpdateToColumns(updateRequest)
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	allergy, err := s.allergyRepo.UpdateAllergyById(patientID, allergyID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("allergy not found")
+		}
+		return nil, err
+	}
+	return mapper.AllergyToResponse(allergy), nil
+}
+
+func (s *AllergyService) DeleteAllergyById(patientIdStr, allergyIdStr string, role any) error {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "update_allergy")
+	if err != nil {
+		return err
+	}
+	patientID := patient.ID
+
+	allergyID, err := parseAllergyID(allergyIdStr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.allergyRepo.DeleteAllergyById(patientID, allergyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("allergy not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func parseAllergyID(allergyIdStr string) (uint, error) {
+	id, err := strconv.ParseUint(allergyIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid allergy ID")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,77 @@
+package allergy_service
+
+import (
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/allergy_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateAllergy_DefaultsToUnconfirmed(t *testing.T) {
+	allergyRepo := new(mocks.MockAllergyRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewAllergyService(allergyRepo, patientRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("CreateAllergy", mock.MatchedBy(func(a *models.Allergy) bool {
+		return a.PatientID == 1 && a.Substance == "Penicillin" && a.Severity == models.AllergySevere &&
+			a.VerificationStatus == models.AllergyUnconfirmed && a.RecordedBy == "7"
+	})).Return(&models.Allergy{ID: 2, PatientID: 1, Substance: "Penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyUnconfirmed}, nil)
+
+	allergyRequest := &request.AllergyRequest{Substance: " Penicillin ", Reaction: "Hives", Severity: "severe"}
+	allergyResponse, err := service.CreateAllergy("1", allergyRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), allergyResponse.ID)
+	assert.Equal(t, "unconfirmed", allergyResponse.VerificationStatus)
+}
+
+func TestCreateAllergy_ReceptionistDenied(t *testing.T) {
+	service := NewAllergyService(new(mocks.MockAllergyRepository), new(patientmocks.MockPatientRepository))
+
+	_, err := service.CreateAllergy("1", &request.AllergyRequest{Substance: "Latex", Severity: "mild"}, "receptionist", "4")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListAllergies_ReceptionistDenied(t *testing.T) {
+	service := NewAllergyService(new(mocks.MockAllergyRepository), new(patientmocks.MockPatientRepository))
+
+	_, err := service.ListAllergies("1", "receptionist")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestUpdateAllergyById_ConfirmsAllergy(t *testing.T) {
+	allergyRepo := new(mocks.MockAllergyRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewAllergyService(allergyRepo, patientRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("UpdateAllergyById", uint(1), uint(2), map[string]interface{}{"verification_status": models.AllergyConfirmed}).
+		Return(&models.Allergy{ID: 2, PatientID: 1, VerificationStatus: models.AllergyConfirmed}, nil)
+
+	status := "confirmed"
+	allergyResponse, err := service.UpdateAllergyById("1", "2", &request.AllergyUpdateRequest{VerificationStatus: &status}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "confirmed", allergyResponse.VerificationStatus)
+}
+
+func TestDeleteAllergyById_NotFound(t *testing.T) {
+	allergyRepo := new(mocks.MockAllergyRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewAllergyService(allergyRepo, patientRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("DeleteAllergyById", uint(1), uint(5)).Return(gorm.ErrRecordNotFound)
+
+	err := service.DeleteAllergyById("1", "5", "doctor")
+
+	assert.EqualError(t, err, "allergy not found")
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAllergyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAllergyRepository) CreateAllergy(allergy *models.Allergy) (*models.Allergy, error) {
+	args := m.Called(allergy)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Allergy), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAllergyRepository) GetAllergyById(patientID, id uint) (*models.Allergy, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Allergy), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAllergyRepository) ListAllergies(patientID uint) ([]models.Allergy, error) {
+	args := m.Called(patientID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Allergy), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAllergyRepository) UpdateAllergyById(patientID, id uint, updates map[string]interface{}) (*models.Allergy, error) {
+	args := m.Called(patientID, id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Allergy), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAllergyRepository) DeleteAllergyById(patientID, id uint) error {
+	args := m.Called(patientID, id)
+	return args.Error(0)
+}
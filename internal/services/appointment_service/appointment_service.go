@@ -0,0 +1,255 @@
+package appointment_service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type AppointmentService struct {
+	appointmentRepo  repository.AppointmentRepository
+	userRepo         repository.UserRepository
+	patientRepo      repository.PatientRepository
+	availabilityRepo repository.AvailabilityRepository
+	location         *time.Location
+}
+
+func NewAppointmentService(appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	patientRepo repository.PatientRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	clinicConfig *config.ClinicConfig) *AppointmentService {
+	return &AppointmentService{
+		appointmentRepo:  appointmentRepo,
+		userRepo:         userRepo,
+		patientRepo:      patientRepo,
+		availabilityRepo: availabilityRepo,
+		location:         clinicConfig.Location,
+	}
+}
+
+func (s *AppointmentService) BookAppointment(appointmentRequest *request.AppointmentRequest, role any) (*response.AppointmentResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "create_appointment"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	if _, err := s.patientRepo.GetPatientById(appointmentRequest.PatientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		return nil, err
+	}
+
+	if err := s.checkDoctor(appointmentRequest.DoctorID); err != nil {
+		return nil, err
+	}
+
+	appointment := mapper.AppointmentToModel(appointmentRequest)
+	if err := s.checkSlot(appointment.DoctorID, appointment.StartTime, appointment.EndTime, 0); err != nil {
+		return nil, err
+	}
+
+	newAppointment, err := s.appointmentRepo.CreateAppointment(appointment)
+	if err != nil {
+		if errors.Is(err, repository.ErrAppointmentOverlap) {
+			return nil, errors.New("time slot already booked")
+		}
+		return nil, err
+	}
+	return mapper.AppointmentToResponse(newAppointment), nil
+}
+
+func (s *AppointmentService) RescheduleAppointment(idStr string, rescheduleRequest *request.RescheduleAppointmentRequest, role any) (*response.AppointmentResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "update_appointment"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	appointment, err := s.getAppointment(idStr)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status == models.AppointmentCancelled {
+		return nil, errors.New("appointment is cancelled")
+	}
+
+	if err := s.checkSlot(appointment.DoctorID, rescheduleRequest.StartTime, rescheduleRequest.EndTime, appointment.ID); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"start_time": rescheduleRequest.StartTime,
+		"end_time":   rescheduleRequest.EndTime,
+	}
+	updated, err := s.appointmentRepo.UpdateAppointmentById(appointment.ID, updates)
+	if err != nil {
+		if errors.Is(err, repository.ErrAppointmentOverlap) {
+			return nil, errors.New("time slot already booked")
+		}
+		return nil, err
+	}
+	return mapper.AppointmentToResponse(updated), nil
+}
+
+func (s *AppointmentService) CancelAppointment(idStr string, role any) (*response.AppointmentResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "cancel_appointment"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	appointment, err := s.getAppointment(idStr)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status == models.AppointmentCancelled {
+		return nil, errors.New("appointment is cancelled")
+	}
+
+	updates := map[string]interface{}{"status": models.AppointmentCancelled}
+	updated, err := s.appointmentRepo.UpdateAppointmentById(appointment.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AppointmentToResponse(updated), nil
+}
+
+func (s *AppointmentService) GetAppointmentById(idStr string, role any, userID string) (*response.AppointmentResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_appointment"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	appointment, err := s.getAppointment(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// doctors may only see their own schedule
+	if roleValue == string(models.Doc) && strconv.FormatUint(uint64(appointment.DoctorID), 10) != userID {
+		return nil, errors.New("permission denied")
+	}
+	return mapper.AppointmentToResponse(appointment), nil
+}
+
+func (s *AppointmentService) ListAppointments(query *request.AppointmentQuery, role any, userID string) ([]*response.AppointmentResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_appointment"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	filter := repository.AppointmentFilter{
+		PatientID: query.PatientID,
+		DoctorID:  query.DoctorID,
+		Status:    models.AppointmentStatus(query.Status),
+	}
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.To = &to
+	}
+
+	// doctors may only see their own schedule
+	if roleValue == string(models.Doc) {
+		doctorID, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid user ID")
+		}
+		filter.DoctorID = uint(doctorID)
+	}
+
+	appointments, err := s.appointmentRepo.ListAppointments(filter)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AppointmentsToResponse(appointments), nil
+}
+
+func (s *AppointmentService) getAppointment(idStr string) (*models.Appointment, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid appointment ID")
+	}
+
+	appointment, err := s.appointmentRepo.GetAppointmentById(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("appointment not found")
+		}
+		return nil, err
+	}
+	return appointment, nil
+}
+
+func (s *AppointmentService) checkDoctor(doctorID uint) error {
+	doctor, err := s.userRepo.GetUserByID(doctorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("doctor not found")
+		}
+		return err
+	}
+	if doctor.Role != models.Doc {
+		return errors.New("doctor not found")
+	}
+	return nil
+}
+
+// checkSlot applies the same working hours and exceptions that GetFreeSlots
+// offers, then rejects overlaps with the doctor's other bookings.
+func (s *AppointmentService) checkSlot(doctorID uint, start, end time.Time, excludeID uint) error {
+	if !end.After(start) {
+		return errors.New("invalid time range")
+	}
+
+	if err := services.CheckWorkingHours(s.availabilityRepo, doctorID, start, end, s.location); err != nil {
+		return err
+	}
+
+	booked, err := s.appointmentRepo.HasOverlappingAppointment(doctorID, start, end, excludeID)
+	if err != nil {
+		return err
+	}
+	if booked {
+		return errors.New("time slot already booked")
+	}
+	return nil
+}
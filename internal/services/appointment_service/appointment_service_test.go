@@ -0,0 +1,200 @@
+package appointment_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services/appointment_service/mocks"
+	availabilitymocks "github.com/palashbhasme/healthcare-portal/internal/services/availability_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var utcClinic = &config.ClinicConfig{Location: time.UTC}
+
+// mondayHours is a working-hours template covering the 09:00 bookings below.
+var mondayHours = []models.WorkingHours{{DoctorID: 2, Weekday: time.Monday, StartTime: "09:00", EndTime: "12:00"}}
+
+func TestBookAppointment_Success(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	availabilityRepo := new(availabilitymocks.MockAvailabilityRepository)
+	service := NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, utcClinic)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).Return(mondayHours, nil)
+	availabilityRepo.On("ListExceptions", uint(2), start, end).Return([]models.AvailabilityException{}, nil)
+	appointmentRepo.On("HasOverlappingAppointment", uint(2), start, end, uint(0)).Return(false, nil)
+	appointmentRepo.On("CreateAppointment", mock.AnythingOfType("*models.Appointment")).
+		Return(&models.Appointment{ID: 5, PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end, Status: models.AppointmentScheduled}, nil)
+
+	result, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), result.ID)
+	assert.Equal(t, "scheduled", result.Status)
+	appointmentRepo.AssertExpectations(t)
+}
+
+func TestBookAppointment_DoubleBooked(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	availabilityRepo := new(availabilitymocks.MockAvailabilityRepository)
+	service := NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, utcClinic)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).Return(mondayHours, nil)
+	availabilityRepo.On("ListExceptions", uint(2), start, end).Return([]models.AvailabilityException{}, nil)
+	appointmentRepo.On("HasOverlappingAppointment", uint(2), start, end, uint(0)).Return(true, nil)
+
+	result, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "time slot already booked", err.Error())
+	appointmentRepo.AssertNotCalled(t, "CreateAppointment", mock.Anything)
+}
+
+func TestBookAppointment_ConcurrentBookingLosesRace(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	availabilityRepo := new(availabilitymocks.MockAvailabilityRepository)
+	service := NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, utcClinic)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).Return(mondayHours, nil)
+	availabilityRepo.On("ListExceptions", uint(2), start, end).Return([]models.AvailabilityException{}, nil)
+	appointmentRepo.On("HasOverlappingAppointment", uint(2), start, end, uint(0)).Return(false, nil)
+	appointmentRepo.On("CreateAppointment", mock.AnythingOfType("*models.Appointment")).Return(nil, repository.ErrAppointmentOverlap)
+
+	_, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.EqualError(t, err, "time slot already booked")
+}
+
+func TestBookAppointment_OutsideWorkingHours(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	availabilityRepo := new(availabilitymocks.MockAvailabilityRepository)
+	service := NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, utcClinic)
+
+	start := time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).Return(mondayHours, nil)
+
+	result, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "outside working hours")
+	appointmentRepo.AssertNotCalled(t, "CreateAppointment", mock.Anything)
+}
+
+func TestBookAppointment_DoctorOnLeave(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	availabilityRepo := new(availabilitymocks.MockAvailabilityRepository)
+	service := NewAppointmentService(appointmentRepo, userRepo, patientRepo, availabilityRepo, utcClinic)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 2, StartTime: start, EndTime: end}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).Return(mondayHours, nil)
+	availabilityRepo.On("ListExceptions", uint(2), start, end).
+		Return([]models.AvailabilityException{{DoctorID: 2, Kind: models.ExceptionLeave}}, nil)
+
+	result, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "doctor unavailable")
+	appointmentRepo.AssertNotCalled(t, "CreateAppointment", mock.Anything)
+}
+
+func TestBookAppointment_NotADoctor(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewAppointmentService(new(mocks.MockAppointmentRepository), userRepo, patientRepo, new(availabilitymocks.MockAvailabilityRepository), utcClinic)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	appointmentReq := &request.AppointmentRequest{PatientID: 1, DoctorID: 3, StartTime: start, EndTime: start.Add(time.Hour)}
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(3)).Return(&models.User{ID: 3, Role: models.Clerk}, nil)
+
+	result, err := service.BookAppointment(appointmentReq, "receptionist")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "doctor not found", err.Error())
+}
+
+func TestBookAppointment_PermissionDenied(t *testing.T) {
+	service := NewAppointmentService(new(mocks.MockAppointmentRepository), new(mocks.MockUserRepository), new(patientmocks.MockPatientRepository), new(availabilitymocks.MockAvailabilityRepository), utcClinic)
+
+	result, err := service.BookAppointment(&request.AppointmentRequest{}, "doctor") // Doctors cannot book
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "permission denied", err.Error())
+}
+
+func TestListAppointments_DoctorSeesOwnOnly(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	service := NewAppointmentService(appointmentRepo, new(mocks.MockUserRepository), new(patientmocks.MockPatientRepository), new(availabilitymocks.MockAvailabilityRepository), utcClinic)
+
+	appointmentRepo.On("ListAppointments", repository.AppointmentFilter{DoctorID: 2}).
+		Return([]models.Appointment{{ID: 1, DoctorID: 2}}, nil)
+
+	// the requested doctor_id is overridden by the caller's own id
+	result, err := service.ListAppointments(&request.AppointmentQuery{DoctorID: 7}, "doctor", "2")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	appointmentRepo.AssertExpectations(t)
+}
+
+func TestRescheduleAppointment_Cancelled(t *testing.T) {
+	appointmentRepo := new(mocks.MockAppointmentRepository)
+	service := NewAppointmentService(appointmentRepo, new(mocks.MockUserRepository), new(patientmocks.MockPatientRepository), new(availabilitymocks.MockAvailabilityRepository), utcClinic)
+
+	appointmentRepo.On("GetAppointmentById", uint(1)).
+		Return(&models.Appointment{ID: 1, DoctorID: 2, Status: models.AppointmentCancelled}, nil)
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	result, err := service.RescheduleAppointment("1", &request.RescheduleAppointmentRequest{StartTime: start, EndTime: start.Add(time.Hour)}, "receptionist")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "appointment is cancelled", err.Error())
+}
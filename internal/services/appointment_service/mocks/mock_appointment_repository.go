@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAppointmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentRepository) CreateAppointment(appointment *models.Appointment) (*models.Appointment, error) {
+	args := m.Called(appointment)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Appointment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) GetAppointmentById(id uint) (*models.Appointment, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Appointment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) UpdateAppointmentById(id uint, updates map[string]interface{}) (*models.Appointment, error) {
+	args := m.Called(id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Appointment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) ListAppointments(filter repository.AppointmentFilter) ([]models.Appointment, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Appointment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) HasOverlappingAppointment(doctorID uint, start, end time.Time, excludeID uint) (bool, error) {
+	args := m.Called(doctorID, start, end, excludeID)
+	return args.Bool(0), args.Error(1)
+}
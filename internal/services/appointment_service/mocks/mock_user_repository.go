@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+	PatchedUpdates map[string]interface{}
+}
+
+func (m *MockUserRepository) CreateUser(user *models.User) (*models.User, error) {
+	args := m.Called(user)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserById(id uint, updates map[string]interface{}) (*models.User, error) {
+	args := m.Called(id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// PatchUserById runs apply against the user registered for id and records the
+// updates it returned in PatchedUpdates.
+func (m *MockUserRepository) PatchUserById(id uint, apply func(current *models.User) (map[string]interface{}, error)) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	current := *args.Get(0).(*models.User)
+	updates, err := apply(&current)
+	if err != nil {
+		return nil, err
+	}
+	m.PatchedUpdates = updates
+	return &current, args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteUserById(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByName(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CheckUserExists(username string) (bool, error) {
+	args := m.Called(username)
+	return args.Bool(0), args.Error(1)
+}
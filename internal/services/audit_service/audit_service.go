@@ -0,0 +1,145 @@
+package audit_service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	verifyBatchSize = 1000
+)
+
+// errChainBroken stops the walk once the first broken link is found.
+var errChainBroken = errors.New("audit chain broken")
+
+// ChainVerification is the outcome of walking the audit hash chain.
+// BrokenAt is the ID of the first event whose link does not verify.
+type ChainVerification struct {
+	Checked  int
+	Valid    bool
+	BrokenAt uint
+	Reason   string
+}
+
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record appends an event to the audit log. The timestamp is always assigned
+// here so callers cannot backdate entries. It is truncated to the precision
+// Postgres stores so the hash can be recomputed from the persisted row.
+func (s *AuditService) Record(event *models.AuditEvent) error {
+	event.ID = 0
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	_, err := s.auditRepo.CreateAuditEvent(event)
+	return err
+}
+
+func (s *AuditService) ListAuditEvents(query *request.AuditQuery, role any) (*response.AuditEventListResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_audit_log"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	filter := repository.AuditFilter{
+		PatientID: query.PatientID,
+		ActorID:   query.UserID,
+		Limit:     query.PageSize,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if query.Page > 1 {
+		filter.Offset = (query.Page - 1) * filter.Limit
+	}
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.To = &to
+	}
+
+	events, total, err := s.auditRepo.ListAuditEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AuditEventListResponse{
+		Events:   mapper.AuditEventsToResponse(events),
+		Total:    total,
+		Page:     filter.Offset/filter.Limit + 1,
+		PageSize: filter.Limit,
+	}, nil
+}
+
+// VerifyChain walks the audit log from the first entry and stops at the first
+// event whose stored hash or predecessor link does not match. When retention
+// has purged the oldest events, the chain is anchored at the hash recorded in
+// the latest checkpoint.
+func (s *AuditService) VerifyChain() (*ChainVerification, error) {
+	result := &ChainVerification{Valid: true}
+	prevHash := ""
+
+	checkpoint, err := s.auditRepo.GetLatestAuditCheckpoint()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if checkpoint != nil {
+		prevHash = checkpoint.LastHash
+	}
+
+	err = s.auditRepo.WalkAuditEvents(verifyBatchSize, func(events []models.AuditEvent) error {
+		for i := range events {
+			event := &events[i]
+			result.Checked++
+
+			switch {
+			case event.PrevHash != prevHash:
+				result.Valid = false
+				result.BrokenAt = event.ID
+				result.Reason = "previous hash does not match the preceding event"
+			case event.ComputeHash() != event.Hash:
+				result.Valid = false
+				result.BrokenAt = event.ID
+				result.Reason = "stored hash does not match event contents"
+			}
+			if !result.Valid {
+				return errChainBroken
+			}
+			prevHash = event.Hash
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package audit_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services/audit_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRecord_AssignsTimestamp(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	backdated := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := &models.AuditEvent{ActorID: "1", Role: "doctor", Action: models.AuditView, CreatedAt: backdated}
+
+	mockRepo.On("CreateAuditEvent", mock.AnythingOfType("*models.AuditEvent")).Return(event, nil)
+
+	err := service.Record(event)
+
+	assert.NoError(t, err)
+	assert.True(t, event.CreatedAt.After(backdated))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListAuditEvents_AdminOnly(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	for _, role := range []string{"doctor", "receptionist"} {
+		result, err := service.ListAuditEvents(&request.AuditQuery{}, role)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "permission denied", err.Error())
+	}
+}
+
+func TestListAuditEvents_Filters(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := repository.AuditFilter{PatientID: 4, ActorID: "2", From: &from, Limit: 50}
+	mockRepo.On("ListAuditEvents", expectedFilter).Return([]models.AuditEvent{{ID: 1, ActorID: "2"}}, int64(1), nil)
+
+	result, err := service.ListAuditEvents(&request.AuditQuery{PatientID: 4, UserID: "2", From: "2025-01-01T00:00:00Z"}, "admin")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Events, 1)
+	assert.Equal(t, "2", result.Events[0].ActorID)
+	mockRepo.AssertExpectations(t)
+}
+
+func chainedEvents(n int) []models.AuditEvent {
+	events := make([]models.AuditEvent, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		event := models.AuditEvent{
+			ID:        uint(i),
+			ActorID:   "1",
+			Role:      "doctor",
+			Resource:  "patient",
+			Action:    models.AuditView,
+			CreatedAt: time.Date(2025, 1, 1, 0, i, 0, 0, time.UTC),
+			PrevHash:  prevHash,
+		}
+		event.Hash = event.ComputeHash()
+		prevHash = event.Hash
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestVerifyChain_Valid(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	mockRepo.On("GetLatestAuditCheckpoint").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(chainedEvents(3), nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, 3, result.Checked)
+}
+
+func TestVerifyChain_EditedEvent(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	events := chainedEvents(4)
+	events[1].ActorID = "2"
+	mockRepo.On("GetLatestAuditCheckpoint").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(events, nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, uint(2), result.BrokenAt)
+	assert.Equal(t, "stored hash does not match event contents", result.Reason)
+}
+
+func TestVerifyChain_EditedResourceID(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	events := chainedEvents(3)
+	noteID, otherNoteID := uint(7), uint(8)
+	events[2].Resource = "note"
+	events[2].ResourceID = &noteID
+	events[2].Hash = events[2].ComputeHash()
+	events[2].ResourceID = &otherNoteID
+	mockRepo.On("GetLatestAuditCheckpoint").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(events, nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, uint(3), result.BrokenAt)
+}
+
+func TestVerifyChain_DeletedEvent(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	events := chainedEvents(4)
+	events = append(events[:1], events[2:]...)
+	mockRepo.On("GetLatestAuditCheckpoint").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(events, nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, uint(3), result.BrokenAt)
+	assert.Equal(t, "previous hash does not match the preceding event", result.Reason)
+}
+
+func TestVerifyChain_AfterRetentionPurge(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	events := chainedEvents(5)
+	checkpoint := &models.AuditCheckpoint{PurgedThroughID: events[1].ID, LastHash: events[1].Hash, PurgedCount: 2}
+	mockRepo.On("GetLatestAuditCheckpoint").Return(checkpoint, nil)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(events[2:], nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, 3, result.Checked)
+}
+
+func TestVerifyChain_PurgeWithoutCheckpoint(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepository)
+	service := NewAuditService(mockRepo)
+
+	events := chainedEvents(5)
+	mockRepo.On("GetLatestAuditCheckpoint").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("WalkAuditEvents", verifyBatchSize, mock.Anything).Return(events[2:], nil)
+
+	result, err := service.VerifyChain()
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, events[2].ID, result.BrokenAt)
+}
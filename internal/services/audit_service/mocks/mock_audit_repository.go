@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	args := m.Called(event)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.AuditEvent), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuditRepository) ListAuditEvents(filter repository.AuditFilter) ([]models.AuditEvent, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.AuditEvent), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockAuditRepository) WalkAuditEvents(batchSize int, fn func(events []models.AuditEvent) error) error {
+	args := m.Called(batchSize, fn)
+	if args.Get(0) != nil {
+		if err := fn(args.Get(0).([]models.AuditEvent)); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockAuditRepository) GetLatestAuditCheckpoint() (*models.AuditCheckpoint, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.AuditCheckpoint), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuditRepository) CountAuditEventsBefore(cutoff time.Time) (int64, error) {
+	args := m.Called(cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAuditRepository) PurgeAuditEventsBefore(cutoff time.Time) (int64, error) {
+	args := m.Called(cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
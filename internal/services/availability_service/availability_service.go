@@ -0,0 +1,288 @@
+package availability_service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+// maxSlotRange bounds how far a single slot search may look ahead.
+const maxSlotRange = 31 * 24 * time.Hour
+
+type AvailabilityService struct {
+	availabilityRepo repository.AvailabilityRepository
+	appointmentRepo  repository.AppointmentRepository
+	userRepo         repository.UserRepository
+	location         *time.Location
+}
+
+func NewAvailabilityService(availabilityRepo repository.AvailabilityRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	clinicConfig *config.ClinicConfig) *AvailabilityService {
+	return &AvailabilityService{
+		availabilityRepo: availabilityRepo,
+		appointmentRepo:  appointmentRepo,
+		userRepo:         userRepo,
+		location:         clinicConfig.Location,
+	}
+}
+
+func (s *AvailabilityService) SetWorkingHours(doctorIdStr string, workingHoursRequest *request.WorkingHoursRequest, role any, userID string) ([]*response.WorkingHoursResponse, error) {
+	doctorID, err := s.authorizeManage(doctorIdStr, role, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// times are compared and stored in their parsed form, since "9:00" and
+	// "09:00" both bind but do not order correctly as strings
+	for i, entry := range workingHoursRequest.Hours {
+		start, err := time.Parse("15:04", entry.StartTime)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		end, err := time.Parse("15:04", entry.EndTime)
+		if err != nil || !end.After(start) {
+			return nil, errors.New("invalid time range")
+		}
+		workingHoursRequest.Hours[i].StartTime = start.Format("15:04")
+		workingHoursRequest.Hours[i].EndTime = end.Format("15:04")
+	}
+
+	hours, err := s.availabilityRepo.ReplaceWorkingHours(doctorID, mapper.WorkingHoursToModel(doctorID, workingHoursRequest))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.WorkingHoursToResponse(hours), nil
+}
+
+func (s *AvailabilityService) GetWorkingHours(doctorIdStr string, role any) ([]*response.WorkingHoursResponse, error) {
+	doctorID, err := s.authorizeView(doctorIdStr, role)
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := s.availabilityRepo.GetWorkingHours(doctorID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.WorkingHoursToResponse(hours), nil
+}
+
+func (s *AvailabilityService) AddException(doctorIdStr string, exceptionRequest *request.AvailabilityExceptionRequest, role any, userID string) (*response.AvailabilityExceptionResponse, error) {
+	doctorID, err := s.authorizeManage(doctorIdStr, role, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exception, err := s.availabilityRepo.CreateException(mapper.AvailabilityExceptionToModel(doctorID, exceptionRequest))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AvailabilityExceptionToResponse(exception), nil
+}
+
+func (s *AvailabilityService) ListExceptions(doctorIdStr string, role any) ([]*response.AvailabilityExceptionResponse, error) {
+	doctorID, err := s.authorizeView(doctorIdStr, role)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	exceptions, err := s.availabilityRepo.ListExceptions(doctorID, now, now.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AvailabilityExceptionsToResponse(exceptions), nil
+}
+
+func (s *AvailabilityService) DeleteException(doctorIdStr, exceptionIdStr string, role any, userID string) error {
+	doctorID, err := s.authorizeManage(doctorIdStr, role, userID)
+	if err != nil {
+		return err
+	}
+
+	exceptionID, err := strconv.ParseUint(exceptionIdStr, 10, 64)
+	if err != nil {
+		return errors.New("invalid exception ID")
+	}
+
+	err = s.availabilityRepo.DeleteException(doctorID, uint(exceptionID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("exception not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetFreeSlots returns the slots of the requested duration that fall inside
+// the doctor's working hours and are not blocked by an exception or an
+// existing booking. Working hours are interpreted in the clinic's time zone;
+// the returned slots are in the time zone of from.
+func (s *AvailabilityService) GetFreeSlots(doctorIdStr string, slotQuery *request.SlotQuery, role any) ([]*response.SlotResponse, error) {
+	doctorID, err := s.authorizeView(doctorIdStr, role)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := time.Parse(time.RFC3339, slotQuery.From)
+	if err != nil {
+		return nil, errors.New("invalid time range")
+	}
+	to, err := time.Parse(time.RFC3339, slotQuery.To)
+	if err != nil {
+		return nil, errors.New("invalid time range")
+	}
+	if !to.After(from) || to.Sub(from) > maxSlotRange {
+		return nil, errors.New("invalid time range")
+	}
+
+	hours, err := s.availabilityRepo.GetWorkingHours(doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := s.availabilityRepo.ListExceptions(doctorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.appointmentRepo.ListAppointments(repository.AppointmentFilter{
+		DoctorID: doctorID,
+		Status:   models.AppointmentScheduled,
+		From:     &from,
+		To:       &to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]timeRange, 0, len(exceptions)+len(appointments))
+	for _, e := range exceptions {
+		busy = append(busy, timeRange{start: e.StartTime, end: e.EndTime})
+	}
+	for _, a := range appointments {
+		busy = append(busy, timeRange{start: a.StartTime, end: a.EndTime})
+	}
+
+	windows := services.WorkingWindows(hours, from, to, s.location)
+	slots := computeSlots(windows, busy, from, to, time.Duration(slotQuery.Duration)*time.Minute)
+
+	slotResponses := make([]*response.SlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		slotResponses = append(slotResponses, &response.SlotResponse{
+			StartTime: slot.start.In(from.Location()),
+			EndTime:   slot.end.In(from.Location()),
+		})
+	}
+	return slotResponses, nil
+}
+
+func (s *AvailabilityService) authorizeView(doctorIdStr string, role any) (uint, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return 0, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_availability"); err != nil {
+		return 0, errors.New("permission denied")
+	}
+
+	return s.getDoctorID(doctorIdStr)
+}
+
+// authorizeManage allows receptionists to manage any calendar but restricts
+// doctors to their own.
+func (s *AvailabilityService) authorizeManage(doctorIdStr string, role any, userID string) (uint, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return 0, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "manage_availability"); err != nil {
+		return 0, errors.New("permission denied")
+	}
+
+	if roleValue == string(models.Doc) && doctorIdStr != userID {
+		return 0, errors.New("permission denied")
+	}
+
+	return s.getDoctorID(doctorIdStr)
+}
+
+func (s *AvailabilityService) getDoctorID(doctorIdStr string) (uint, error) {
+	id, err := strconv.ParseUint(doctorIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid doctor ID")
+	}
+
+	doctor, err := s.userRepo.GetUserByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("doctor not found")
+		}
+		return 0, err
+	}
+	if doctor.Role != models.Doc {
+		return 0, errors.New("doctor not found")
+	}
+	return doctor.ID, nil
+}
+
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func (r timeRange) overlaps(other timeRange) bool {
+	return r.start.Before(other.end) && other.start.Before(r.end)
+}
+
+// computeSlots cuts each working-hours window into back-to-back slots of the
+// given duration, keeping only those inside [from, to). A slot that collides
+// with a busy range is dropped and the next slot starts where that range ends.
+func computeSlots(windows []services.TimeRange, busy []timeRange, from, to time.Time, duration time.Duration) []timeRange {
+	slots := []timeRange{}
+
+	for _, w := range windows {
+		windowEnd := w.End
+		if windowEnd.After(to) {
+			windowEnd = to
+		}
+
+		cursor := w.Start
+		for !cursor.Add(duration).After(windowEnd) {
+			slot := timeRange{start: cursor, end: cursor.Add(duration)}
+			if cursor.Before(from) {
+				cursor = slot.end
+				continue
+			}
+
+			blockedUntil := time.Time{}
+			for _, b := range busy {
+				if slot.overlaps(b) && b.end.After(blockedUntil) {
+					blockedUntil = b.end
+				}
+			}
+			if !blockedUntil.IsZero() {
+				cursor = blockedUntil
+				continue
+			}
+
+			slots = append(slots, slot)
+			cursor = slot.end
+		}
+	}
+	return slots
+}
@@ -0,0 +1,157 @@
+package availability_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	appointmentmocks "github.com/palashbhasme/healthcare-portal/internal/services/appointment_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/availability_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 2025-06-02 is a Monday
+var monday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+var utcClinic = &config.ClinicConfig{Location: time.UTC}
+
+func TestComputeSlots_SplitsWorkingHours(t *testing.T) {
+	hours := []models.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"}}
+
+	slots := computeSlots(services.WorkingWindows(hours, monday, monday.AddDate(0, 0, 1), time.UTC), nil, monday, monday.AddDate(0, 0, 1), 20*time.Minute)
+
+	assert.Len(t, slots, 3)
+	assert.Equal(t, monday.Add(9*time.Hour), slots[0].start)
+	assert.Equal(t, monday.Add(9*time.Hour+40*time.Minute), slots[2].start)
+}
+
+func TestComputeSlots_SkipsBusyRanges(t *testing.T) {
+	hours := []models.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "11:00"}}
+	busy := []timeRange{{start: monday.Add(9*time.Hour + 15*time.Minute), end: monday.Add(10 * time.Hour)}}
+
+	slots := computeSlots(services.WorkingWindows(hours, monday, monday.AddDate(0, 0, 1), time.UTC), busy, monday, monday.AddDate(0, 0, 1), 30*time.Minute)
+
+	// 09:00 collides, so slots resume at 10:00
+	assert.Len(t, slots, 2)
+	assert.Equal(t, monday.Add(10*time.Hour), slots[0].start)
+	assert.Equal(t, monday.Add(10*time.Hour+30*time.Minute), slots[1].start)
+}
+
+func TestComputeSlots_IgnoresOtherWeekdaysAndPastFrom(t *testing.T) {
+	hours := []models.WorkingHours{
+		{Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"},
+		{Weekday: time.Tuesday, StartTime: "09:00", EndTime: "10:00"},
+	}
+
+	from := monday.Add(9*time.Hour + 10*time.Minute)
+	slots := computeSlots(services.WorkingWindows(hours, from, monday.AddDate(0, 0, 1), time.UTC), nil, from, monday.AddDate(0, 0, 1), 30*time.Minute)
+
+	assert.Len(t, slots, 1)
+	assert.Equal(t, monday.Add(9*time.Hour+30*time.Minute), slots[0].start)
+}
+
+func TestGetFreeSlots_Success(t *testing.T) {
+	availabilityRepo := new(mocks.MockAvailabilityRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewAvailabilityService(availabilityRepo, appointmentRepo, userRepo, utcClinic)
+
+	from := monday
+	to := monday.AddDate(0, 0, 1)
+
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).
+		Return([]models.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"}}, nil)
+	availabilityRepo.On("ListExceptions", uint(2), from, to).Return([]models.AvailabilityException{}, nil)
+	appointmentRepo.On("ListAppointments", mock.Anything).Return([]models.Appointment{
+		{DoctorID: 2, StartTime: monday.Add(9 * time.Hour), EndTime: monday.Add(9*time.Hour + 30*time.Minute)},
+	}, nil)
+
+	slotQuery := &request.SlotQuery{From: from.Format(time.RFC3339), To: to.Format(time.RFC3339), Duration: 30}
+	result, err := service.GetFreeSlots("2", slotQuery, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, monday.Add(9*time.Hour+30*time.Minute), result[0].StartTime)
+}
+
+func TestGetFreeSlots_UsesClinicTimeZone(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+
+	availabilityRepo := new(mocks.MockAvailabilityRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewAvailabilityService(availabilityRepo, appointmentRepo, userRepo, &config.ClinicConfig{Location: kolkata})
+
+	from := monday
+	to := monday.AddDate(0, 0, 1)
+
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	availabilityRepo.On("GetWorkingHours", uint(2)).
+		Return([]models.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"}}, nil)
+	availabilityRepo.On("ListExceptions", uint(2), from, to).Return([]models.AvailabilityException{}, nil)
+	appointmentRepo.On("ListAppointments", mock.Anything).Return([]models.Appointment{}, nil)
+
+	// a UTC query still gets the clinic's 09:00, which is 03:30 UTC
+	slotQuery := &request.SlotQuery{From: from.Format(time.RFC3339), To: to.Format(time.RFC3339), Duration: 60}
+	result, err := service.GetFreeSlots("2", slotQuery, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.True(t, monday.Add(3*time.Hour+30*time.Minute).Equal(result[0].StartTime))
+}
+
+func TestSetWorkingHours_DoctorCannotEditOthers(t *testing.T) {
+	service := NewAvailabilityService(new(mocks.MockAvailabilityRepository),
+		new(appointmentmocks.MockAppointmentRepository), new(appointmentmocks.MockUserRepository), utcClinic)
+
+	result, err := service.SetWorkingHours("3", &request.WorkingHoursRequest{}, "doctor", "2")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "permission denied", err.Error())
+}
+
+func TestSetWorkingHours_ComparesParsedTimes(t *testing.T) {
+	availabilityRepo := new(mocks.MockAvailabilityRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewAvailabilityService(availabilityRepo, new(appointmentmocks.MockAppointmentRepository), userRepo, utcClinic)
+
+	weekday := int(time.Monday)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+	expected := []models.WorkingHours{{DoctorID: 2, Weekday: time.Monday, StartTime: "09:00", EndTime: "17:00"}}
+	availabilityRepo.On("ReplaceWorkingHours", uint(2), expected).Return(expected, nil)
+
+	// "9:00" sorts after "17:00" as a string
+	result, err := service.SetWorkingHours("2", &request.WorkingHoursRequest{
+		Hours: []request.WorkingHoursEntry{{Weekday: &weekday, StartTime: "9:00", EndTime: "17:00"}},
+	}, "doctor", "2")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	availabilityRepo.AssertExpectations(t)
+}
+
+func TestSetWorkingHours_RejectsEndBeforeStart(t *testing.T) {
+	availabilityRepo := new(mocks.MockAvailabilityRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewAvailabilityService(availabilityRepo, new(appointmentmocks.MockAppointmentRepository), userRepo, utcClinic)
+
+	weekday := int(time.Monday)
+	userRepo.On("GetUserByID", uint(2)).Return(&models.User{ID: 2, Role: models.Doc}, nil)
+
+	// "10:00" sorts before "9:30" as a string
+	result, err := service.SetWorkingHours("2", &request.WorkingHoursRequest{
+		Hours: []request.WorkingHoursEntry{{Weekday: &weekday, StartTime: "10:00", EndTime: "9:30"}},
+	}, "doctor", "2")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid time range", err.Error())
+	availabilityRepo.AssertNotCalled(t, "ReplaceWorkingHours", mock.Anything, mock.Anything)
+}
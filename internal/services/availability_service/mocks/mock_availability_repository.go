@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) ReplaceWorkingHours(doctorID uint, hours []models.WorkingHours) ([]models.WorkingHours, error) {
+	args := m.Called(doctorID, hours)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WorkingHours), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) GetWorkingHours(doctorID uint) ([]models.WorkingHours, error) {
+	args := m.Called(doctorID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WorkingHours), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) CreateException(exception *models.AvailabilityException) (*models.AvailabilityException, error) {
+	args := m.Called(exception)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.AvailabilityException), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ListExceptions(doctorID uint, from, to time.Time) ([]models.AvailabilityException, error) {
+	args := m.Called(doctorID, from, to)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.AvailabilityException), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) DeleteException(doctorID, id uint) error {
+	args := m.Called(doctorID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,156 @@
+package code_service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+)
+
+const defaultSearchLimit = 20
+
+// bundledICD10 is a starter subset of ICD-10-CM used when the catalog is
+// empty. It is far from complete, so condition codes missing from it are only
+// checked for format until the full CMS code table is loaded with the
+// import-icd10 command.
+//
+//go:embed icd10cm.csv
+var bundledICD10 []byte
+
+type CodeService struct {
+	codeRepo repository.CodeRepository
+}
+
+func NewCodeService(codeRepo repository.CodeRepository) *CodeService {
+	return &CodeService{
+		codeRepo: codeRepo,
+	}
+}
+
+func (s *CodeService) SearchICD10(codeQuery *request.CodeQuery, role any) ([]*response.CodeResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "search_codes"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	term := strings.TrimSpace(codeQuery.Q)
+	if term == "" {
+		return nil, errors.New("search term is required")
+	}
+
+	limit := codeQuery.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	codes, err := s.codeRepo.SearchICD10Codes(term, limit)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.CodesToResponse(codes), nil
+}
+
+// ImportICD10 replaces the catalog with the codes read from r and returns how
+// many were loaded.
+func (s *CodeService) ImportICD10(r io.Reader) (int, error) {
+	codes, err := parseICD10CSV(r)
+	if err != nil {
+		return 0, err
+	}
+	if len(codes) == 0 {
+		return 0, errors.New("ICD-10 file contains no codes")
+	}
+
+	if err := s.codeRepo.ReplaceICD10Codes(codes); err != nil {
+		return 0, err
+	}
+	return len(codes), nil
+}
+
+// ICD10CatalogImported reports whether a full code table has been imported,
+// as opposed to only the bundled subset.
+func (s *CodeService) ICD10CatalogImported() (bool, error) {
+	count, err := s.codeRepo.CountImportedICD10Codes()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// LoadBundledICD10 imports the bundled catalog if no codes are loaded yet, so
+// an imported CMS table is never overwritten at startup.
+func (s *CodeService) LoadBundledICD10() (int, error) {
+	count, err := s.codeRepo.CountICD10Codes()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	codes, err := parseICD10CSV(bytes.NewReader(bundledICD10))
+	if err != nil {
+		return 0, err
+	}
+	for i := range codes {
+		codes[i].Bundled = true
+	}
+
+	if err := s.codeRepo.ReplaceICD10Codes(codes); err != nil {
+		return 0, err
+	}
+	return len(codes), nil
+}
+
+// parseICD10CSV reads code,description rows. A header row is skipped, and
+// codes may be given with or without the dot.
+func parseICD10CSV(r io.Reader) ([]models.ICD10Code, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var codes []models.ICD10Code
+	seen := map[string]bool{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "code") {
+			continue
+		}
+
+		code, err := services.NormalizeICD10Code(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ICD-10 code %q", line, record[0])
+		}
+		description := strings.TrimSpace(record[1])
+		if description == "" {
+			return nil, fmt.Errorf("line %d: missing description for %s", line, code)
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("line %d: duplicate code %s", line, code)
+		}
+		seen[code] = true
+
+		codes = append(codes, models.ICD10Code{Code: code, Description: description})
+	}
+	return codes, nil
+}
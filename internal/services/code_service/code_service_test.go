@@ -0,0 +1,100 @@
+package code_service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/code_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseICD10CSV_NormalizesCodes(t *testing.T) {
+	codes, err := parseICD10CSV(strings.NewReader("code,description\ne119,Type 2 diabetes mellitus without complications\nI10,Essential (primary) hypertension\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ICD10Code{
+		{Code: "E11.9", Description: "Type 2 diabetes mellitus without complications"},
+		{Code: "I10", Description: "Essential (primary) hypertension"},
+	}, codes)
+}
+
+func TestParseICD10CSV_RejectsBadRows(t *testing.T) {
+	cases := map[string]string{
+		"invalid code":        "E11.9,Diabetes\n12X,Nonsense\n",
+		"missing description": "E11.9,\n",
+		"duplicate code":      "E11.9,Diabetes\nE119,Diabetes again\n",
+		"wrong field count":   "E11.9\n",
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseICD10CSV(strings.NewReader(body))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBundledICD10_Parses(t *testing.T) {
+	codes, err := parseICD10CSV(bytes.NewReader(bundledICD10))
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, codes)
+}
+
+func TestLoadBundledICD10_SkipsLoadedCatalog(t *testing.T) {
+	codeRepo := new(mocks.MockCodeRepository)
+	service := NewCodeService(codeRepo)
+
+	codeRepo.On("CountICD10Codes").Return(int64(72000), nil)
+
+	loaded, err := service.LoadBundledICD10()
+
+	assert.NoError(t, err)
+	assert.Zero(t, loaded)
+	codeRepo.AssertNotCalled(t, "ReplaceICD10Codes", mock.Anything)
+}
+
+func TestLoadBundledICD10_ImportsIntoEmptyCatalog(t *testing.T) {
+	codeRepo := new(mocks.MockCodeRepository)
+	service := NewCodeService(codeRepo)
+
+	codeRepo.On("CountICD10Codes").Return(int64(0), nil)
+	codeRepo.On("ReplaceICD10Codes", mock.MatchedBy(func(codes []models.ICD10Code) bool {
+		for _, c := range codes {
+			if !c.Bundled {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+
+	loaded, err := service.LoadBundledICD10()
+
+	assert.NoError(t, err)
+	assert.Positive(t, loaded)
+}
+
+func TestSearchICD10_DefaultLimit(t *testing.T) {
+	codeRepo := new(mocks.MockCodeRepository)
+	service := NewCodeService(codeRepo)
+
+	codeRepo.On("SearchICD10Codes", "diabetes", defaultSearchLimit).
+		Return([]models.ICD10Code{{Code: "E11.9", Description: "Type 2 diabetes mellitus without complications"}}, nil)
+
+	codes, err := service.SearchICD10(&request.CodeQuery{Q: " diabetes "}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 1)
+	assert.Equal(t, "E11.9", codes[0].Code)
+}
+
+func TestSearchICD10_PermissionDenied(t *testing.T) {
+	service := NewCodeService(new(mocks.MockCodeRepository))
+
+	_, err := service.SearchICD10(&request.CodeQuery{Q: "E11"}, "admin")
+
+	assert.EqualError(t, err, "permission denied")
+}
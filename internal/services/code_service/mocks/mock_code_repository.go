@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockCodeRepository) ReplaceICD10Codes(codes []models.ICD10Code) error {
+	args := m.Called(codes)
+	return args.Error(0)
+}
+
+func (m *MockCodeRepository) CountICD10Codes() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCodeRepository) CountImportedICD10Codes() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCodeRepository) GetICD10Code(code string) (*models.ICD10Code, error) {
+	args := m.Called(code)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ICD10Code), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCodeRepository) SearchICD10Codes(term string, limit int) ([]models.ICD10Code, error) {
+	args := m.Called(term, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.ICD10Code), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,194 @@
+package condition_service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type ConditionService struct {
+	conditionRepo repository.ConditionRepository
+	patientRepo   repository.PatientRepository
+	codeRepo      repository.CodeRepository
+	encounterRepo repository.EncounterRepository
+}
+
+func NewConditionService(conditionRepo repository.ConditionRepository,
+	patientRepo repository.PatientRepository,
+	codeRepo repository.CodeRepository,
+	encounterRepo repository.EncounterRepository) *ConditionService {
+	return &ConditionService{
+		conditionRepo: conditionRepo,
+		patientRepo:   patientRepo,
+		codeRepo:      codeRepo,
+		encounterRepo: encounterRepo,
+	}
+}
+
+func (s *ConditionService) CreateCondition(patientIdStr string, conditionRequest *request.ConditionRequest, role any, userID string) (*response.ConditionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_condition")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	if err := services.CheckEncounter(s.encounterRepo, patientID, conditionRequest.EncounterID); err != nil {
+		return nil, err
+	}
+
+	icd10Code, err := s.lookupCode(conditionRequest.Code)
+	if err != nil {
+		return nil, err
+	}
+	conditionRequest.Code = icd10Code.Code
+	if conditionRequest.Description == "" {
+		conditionRequest.Description = icd10Code.Description
+	}
+
+	condition, err := mapper.ConditionToModel(patientID, conditionRequest, userID)
+	if err != nil {
+		return nil, errors.New("invalid onset date")
+	}
+
+	newCondition, err := s.conditionRepo.CreateCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ConditionToResponse(newCondition), nil
+}
+
+func (s *ConditionService) ListConditions(patientIdStr string, conditionQuery *request.ConditionQuery, role any) ([]*response.ConditionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_condition")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	conditions, err := s.conditionRepo.ListConditions(patientID, models.ConditionStatus(conditionQuery.Status))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ConditionsToResponse(conditions), nil
+}
+
+func (s *ConditionService) GetConditionById(patientIdStr, conditionIdStr string, role any) (*response.ConditionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_condition")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	conditionID, err := parseConditionID(conditionIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := s.conditionRepo.GetConditionById(patientID, conditionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("condition not found")
+		}
+		return nil, err
+	}
+	return mapper.ConditionToResponse(condition), nil
+}
+
+func (s *ConditionService) UpdateConditionById(patientIdStr, conditionIdStr string, updateRequest *request.ConditionUpdateRequest, role any) (*response.ConditionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_condition")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	conditionID, err := parseConditionID(conditionIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateRequest.Code != nil {
+		icd10Code, err := s.lookupCode(*updateRequest.Code)
+		if err != nil {
+			return nil, err
+		}
+		updateRequest.Code = &icd10Code.Code
+	}
+
+	updates, err := mapper.ConditionUpdateToColumns(updateRequest)
+	if err != nil {
+		return nil, errors.New("invalid onset date")
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	condition, err := s.conditionRepo.UpdateConditionById(patientID, conditionID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("condition not found")
+		}
+		return nil, err
+	}
+	return mapper.ConditionToResponse(condition), nil
+}
+
+func (s *ConditionService) DeleteConditionById(patientIdStr, conditionIdStr string, role any) error {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_condition")
+	if err != nil {
+		return err
+	}
+	patientID := patient.ID
+
+	conditionID, err := parseConditionID(conditionIdStr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.conditionRepo.DeleteConditionById(patientID, conditionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("condition not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func parseConditionID(conditionIdStr string) (uint, error) {
+	id, err := strconv.ParseUint(conditionIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid condition ID")
+	}
+	return uint(id), nil
+}
+
+// lookupCode normalizes code and finds it in the ICD-10 catalog. While only
+// the bundled subset is loaded a missing code cannot be told apart from an
+// invalid one, so it is accepted on its format alone.
+func (s *ConditionService) lookupCode(code string) (*models.ICD10Code, error) {
+	code, err := services.NormalizeICD10Code(code)
+	if err != nil {
+		return nil, err
+	}
+
+	icd10Code, err := s.codeRepo.GetICD10Code(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			imported, countErr := s.codeRepo.CountImportedICD10Codes()
+			if countErr != nil {
+				return nil, countErr
+			}
+			if imported == 0 {
+				return &models.ICD10Code{Code: code}, nil
+			}
+			return nil, errors.New("unknown ICD-10 code")
+		}
+		return nil, err
+	}
+	return icd10Code, nil
+}
@@ -0,0 +1,185 @@
+package condition_service
+
+import (
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	codemocks "github.com/palashbhasme/healthcare-portal/internal/services/code_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/condition_service/mocks"
+	encountermocks "github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateCondition_Success(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	codeRepo.On("GetICD10Code", "E11.9").Return(&models.ICD10Code{Code: "E11.9", Description: "Type 2 diabetes mellitus without complications"}, nil)
+	conditionRepo.On("CreateCondition", mock.MatchedBy(func(c *models.Condition) bool {
+		return c.PatientID == 1 && c.Code == "E11.9" && c.Status == models.ConditionActive &&
+			c.RecordedBy == "7" && c.OnsetDate != nil && c.Description == "Type 2 diabetes mellitus without complications"
+	})).Return(&models.Condition{ID: 3, PatientID: 1, Code: "E11.9", Status: models.ConditionActive, RecordedBy: "7"}, nil)
+
+	conditionRequest := &request.ConditionRequest{Code: " e119 ", OnsetDate: "2020-03-01"}
+	conditionResponse, err := service.CreateCondition("1", conditionRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), conditionResponse.ID)
+	assert.Equal(t, "E11.9", conditionResponse.Code)
+	conditionRepo.AssertExpectations(t)
+}
+
+func TestCreateCondition_InvalidCode(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	for _, code := range []string{"diabetes", "1E1", "E1", "E11.12345"} {
+		_, err := service.CreateCondition("1", &request.ConditionRequest{Code: code}, "doctor", "7")
+		assert.EqualError(t, err, "invalid ICD-10 code", code)
+	}
+	conditionRepo.AssertNotCalled(t, "CreateCondition", mock.Anything)
+}
+
+func TestCreateCondition_CodeNotInCatalog(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	codeRepo.On("GetICD10Code", "E11.99").Return(nil, gorm.ErrRecordNotFound)
+	codeRepo.On("CountImportedICD10Codes").Return(int64(72000), nil)
+
+	_, err := service.CreateCondition("1", &request.ConditionRequest{Code: "E11.99"}, "doctor", "7")
+
+	assert.EqualError(t, err, "unknown ICD-10 code")
+	conditionRepo.AssertNotCalled(t, "CreateCondition", mock.Anything)
+}
+
+func TestCreateCondition_BundledCatalogAcceptsUnlistedCode(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	codeRepo.On("GetICD10Code", "S72.001A").Return(nil, gorm.ErrRecordNotFound)
+	codeRepo.On("CountImportedICD10Codes").Return(int64(0), nil)
+	conditionRepo.On("CreateCondition", mock.MatchedBy(func(c *models.Condition) bool {
+		return c.Code == "S72.001A" && c.Description == "Hip fracture"
+	})).Return(&models.Condition{ID: 3, PatientID: 1, Code: "S72.001A", Description: "Hip fracture"}, nil)
+
+	result, err := service.CreateCondition("1", &request.ConditionRequest{Code: "s72001a", Description: "Hip fracture"}, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "S72.001A", result.Code)
+	conditionRepo.AssertExpectations(t)
+}
+
+func TestCreateCondition_EncounterOfAnotherPatient(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	encounterRepo := new(encountermocks.MockEncounterRepository)
+	service := NewConditionService(conditionRepo, patientRepo, new(codemocks.MockCodeRepository), encounterRepo)
+
+	encounterID := uint(9)
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	encounterRepo.On("GetEncounterById", uint(1), encounterID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.CreateCondition("1", &request.ConditionRequest{Code: "E11.9", EncounterID: &encounterID}, "doctor", "7")
+
+	assert.EqualError(t, err, "encounter not found")
+	conditionRepo.AssertNotCalled(t, "CreateCondition", mock.Anything)
+}
+
+func TestCreateCondition_ReceptionistDenied(t *testing.T) {
+	service := NewConditionService(new(mocks.MockConditionRepository), new(patientmocks.MockPatientRepository), new(codemocks.MockCodeRepository), new(encountermocks.MockEncounterRepository))
+
+	_, err := service.CreateCondition("1", &request.ConditionRequest{Code: "E11.9"}, "receptionist", "4")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListConditions_PatientNotFound(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.ListConditions("9", &request.ConditionQuery{}, "doctor")
+
+	assert.EqualError(t, err, "patient not found")
+}
+
+func TestListConditions_FiltersByStatus(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	conditionRepo.On("ListConditions", uint(1), models.ConditionResolved).
+		Return([]models.Condition{{ID: 2, PatientID: 1, Code: "J45", Status: models.ConditionResolved}}, nil)
+
+	conditions, err := service.ListConditions("1", &request.ConditionQuery{Status: "resolved"}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, "resolved", conditions[0].Status)
+}
+
+func TestUpdateConditionById_ResolvesCondition(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	conditionRepo.On("UpdateConditionById", uint(1), uint(2), map[string]interface{}{"status": models.ConditionResolved}).
+		Return(&models.Condition{ID: 2, PatientID: 1, Status: models.ConditionResolved}, nil)
+
+	status := "resolved"
+	conditionResponse, err := service.UpdateConditionById("1", "2", &request.ConditionUpdateRequest{Status: &status}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "resolved", conditionResponse.Status)
+}
+
+func TestUpdateConditionById_NoFields(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(new(mocks.MockConditionRepository), patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	_, err := service.UpdateConditionById("1", "2", &request.ConditionUpdateRequest{}, "doctor")
+
+	assert.EqualError(t, err, "no fields to update")
+}
+
+func TestDeleteConditionById_NotFound(t *testing.T) {
+	conditionRepo := new(mocks.MockConditionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	codeRepo := new(codemocks.MockCodeRepository)
+	service := NewConditionService(conditionRepo, patientRepo, codeRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	conditionRepo.On("DeleteConditionById", uint(1), uint(5)).Return(gorm.ErrRecordNotFound)
+
+	err := service.DeleteConditionById("1", "5", "doctor")
+
+	assert.EqualError(t, err, "condition not found")
+}
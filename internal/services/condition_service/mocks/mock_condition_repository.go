@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockConditionRepository struct {
+	mock.Mock
+}
+
+func (m *MockConditionRepository) CreateCondition(condition *models.Condition) (*models.Condition, error) {
+	args := m.Called(condition)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Condition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockConditionRepository) GetConditionById(patientID, id uint) (*models.Condition, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Condition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockConditionRepository) ListConditions(patientID uint, status models.ConditionStatus) ([]models.Condition, error) {
+	args := m.Called(patientID, status)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Condition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockConditionRepository) UpdateConditionById(patientID, id uint, updates map[string]interface{}) (*models.Condition, error) {
+	args := m.Called(patientID, id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Condition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockConditionRepository) DeleteConditionById(patientID, id uint) error {
+	args := m.Called(patientID, id)
+	return args.Error(0)
+}
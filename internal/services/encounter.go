@@ -0,0 +1,25 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// CheckEncounter verifies that encounterID, when set, is one of the
+// patient's encounters so clinical data is never filed under another
+// patient's visit.
+func CheckEncounter(encounterRepo repository.EncounterRepository, patientID uint, encounterID *uint) error {
+	if encounterID == nil {
+		return nil
+	}
+
+	if _, err := encounterRepo.GetEncounterById(patientID, *encounterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("encounter not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package encounter_service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+// clockSkew is how far in the future a check-in time may be.
+const clockSkew = 5 * time.Minute
+
+type EncounterService struct {
+	encounterRepo    repository.EncounterRepository
+	patientRepo      repository.PatientRepository
+	appointmentRepo  repository.AppointmentRepository
+	userRepo         repository.UserRepository
+	vitalRepo        repository.VitalRepository
+	conditionRepo    repository.ConditionRepository
+	prescriptionRepo repository.PrescriptionRepository
+	noteRepo         repository.NoteRepository
+	labRepo          repository.LabRepository
+}
+
+func NewEncounterService(encounterRepo repository.EncounterRepository,
+	patientRepo repository.PatientRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	vitalRepo repository.VitalRepository,
+	conditionRepo repository.ConditionRepository,
+	prescriptionRepo repository.PrescriptionRepository,
+	noteRepo repository.NoteRepository,
+	labRepo repository.LabRepository) *EncounterService {
+	return &EncounterService{
+		encounterRepo:    encounterRepo,
+		patientRepo:      patientRepo,
+		appointmentRepo:  appointmentRepo,
+		userRepo:         userRepo,
+		vitalRepo:        vitalRepo,
+		conditionRepo:    conditionRepo,
+		prescriptionRepo: prescriptionRepo,
+		noteRepo:         noteRepo,
+		labRepo:          labRepo,
+	}
+}
+
+// CheckIn opens an encounter. A booked visit takes its doctor and reason from
+// the appointment, which can only be checked in once.
+func (s *EncounterService) CheckIn(patientIdStr string, encounterRequest *request.EncounterRequest, role any, userID string) (*response.EncounterResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_encounter")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounter, err := mapper.EncounterToModel(patientID, encounterRequest, userID)
+	if err != nil {
+		return nil, errors.New("invalid check_in_at")
+	}
+	if encounter.CheckInAt.After(time.Now().Add(clockSkew)) {
+		return nil, errors.New("check_in_at is in the future")
+	}
+
+	if encounter.AppointmentID != nil {
+		if err := s.applyAppointment(encounter); err != nil {
+			return nil, err
+		}
+	}
+	if encounter.DoctorID == 0 && role == string(models.Doc) {
+		doctorID, err := strconv.ParseUint(userID, 10, 64)
+		if err == nil {
+			encounter.DoctorID = uint(doctorID)
+		}
+	}
+	if err := s.checkDoctor(encounter.DoctorID); err != nil {
+		return nil, err
+	}
+
+	encounter, err = s.encounterRepo.CreateEncounter(encounter)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.EncounterToResponse(encounter), nil
+}
+
+func (s *EncounterService) CheckOut(patientIdStr, encounterIdStr string, role any) (*response.EncounterResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_encounter")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounter, err := s.getEncounter(patientID, encounterIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if encounter.CheckOutAt != nil {
+		return nil, errors.New("encounter already checked out")
+	}
+
+	encounter, err = s.encounterRepo.CheckOutEncounter(patientID, encounter.ID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("encounter already checked out")
+		}
+		return nil, err
+	}
+	return mapper.EncounterToResponse(encounter), nil
+}
+
+// ListEncounters returns the patient's visit timeline, most recent first,
+// with the clinical data recorded during each visit nested under it.
+func (s *EncounterService) ListEncounters(patientIdStr string, role any) ([]*response.EncounterResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_encounter")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounters, err := s.encounterRepo.ListEncounters(patientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withClinicalData(patientID, encounters, role.(string))
+}
+
+func (s *EncounterService) GetEncounterById(patientIdStr, encounterIdStr string, role any) (*response.EncounterResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_encounter")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounter, err := s.getEncounter(patientID, encounterIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	encounterResponses, err := s.withClinicalData(patientID, []models.Encounter{*encounter}, role.(string))
+	if err != nil {
+		return nil, err
+	}
+	return encounterResponses[0], nil
+}
+
+// withClinicalData converts encounters and nests the vitals, conditions,
+// prescriptions, notes and lab orders linked to each. A section is left null
+// when the role lacks its view permission, as in the patient summary; lab
+// orders without results are shown to roles that may follow their status.
+func (s *EncounterService) withClinicalData(patientID uint, encounters []models.Encounter, role string) ([]*response.EncounterResponse, error) {
+	encounterResponses := make([]*response.EncounterResponse, 0, len(encounters))
+	byID := make(map[uint]*response.EncounterResponse, len(encounters))
+	for i := range encounters {
+		encounterResponse := mapper.EncounterToResponse(&encounters[i])
+		encounterResponses = append(encounterResponses, encounterResponse)
+		byID[encounters[i].ID] = encounterResponse
+	}
+	if len(encounters) == 0 {
+		return encounterResponses, nil
+	}
+
+	if services.CheckPermission(role, "view_vitals") == nil {
+		vitals, err := s.vitalRepo.ListVitals(patientID, repository.VitalFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, encounterResponse := range encounterResponses {
+			encounterResponse.Vitals = []*response.VitalResponse{}
+		}
+		for i := range vitals {
+			if encounterResponse := linked(byID, vitals[i].EncounterID); encounterResponse != nil {
+				encounterResponse.Vitals = append(encounterResponse.Vitals, mapper.VitalToResponse(&vitals[i], vitals[i].ComputeBMI(vitals)))
+			}
+		}
+	}
+
+	if services.CheckPermission(role, "view_condition") == nil {
+		conditions, err := s.conditionRepo.ListConditions(patientID, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, encounterResponse := range encounterResponses {
+			encounterResponse.Conditions = []*response.ConditionResponse{}
+		}
+		for i := range conditions {
+			if encounterResponse := linked(byID, conditions[i].EncounterID); encounterResponse != nil {
+				encounterResponse.Conditions = append(encounterResponse.Conditions, mapper.ConditionToResponse(&conditions[i]))
+			}
+		}
+	}
+
+	if services.CheckPermission(role, "view_prescription") == nil {
+		prescriptions, err := s.prescriptionRepo.ListPrescriptions(patientID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, encounterResponse := range encounterResponses {
+			encounterResponse.Prescriptions = []*response.PrescriptionResponse{}
+		}
+		for i := range prescriptions {
+			if encounterResponse := linked(byID, prescriptions[i].EncounterID); encounterResponse != nil {
+				encounterResponse.Prescriptions = append(encounterResponse.Prescriptions, mapper.PrescriptionToResponse(&prescriptions[i]))
+			}
+		}
+	}
+
+	if services.CheckPermission(role, "view_note") == nil {
+		notes, err := s.noteRepo.ListNotes(patientID, repository.NoteFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, encounterResponse := range encounterResponses {
+			encounterResponse.Notes = []*response.NoteResponse{}
+		}
+		for i := range notes {
+			if encounterResponse := linked(byID, &notes[i].EncounterID); encounterResponse != nil {
+				encounterResponse.Notes = append(encounterResponse.Notes, mapper.NoteToResponse(&notes[i]))
+			}
+		}
+	}
+
+	includeResults := services.CheckPermission(role, "view_lab") == nil
+	if includeResults || services.CheckPermission(role, "view_lab_status") == nil {
+		orders, err := s.labRepo.ListLabOrders(patientID, repository.LabOrderFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, encounterResponse := range encounterResponses {
+			encounterResponse.LabOrders = []*response.LabOrderResponse{}
+		}
+		for i := range orders {
+			if encounterResponse := linked(byID, orders[i].EncounterID); encounterResponse != nil {
+				encounterResponse.LabOrders = append(encounterResponse.LabOrders, mapper.LabOrderToResponse(&orders[i], includeResults))
+			}
+		}
+	}
+
+	return encounterResponses, nil
+}
+
+// applyAppointment links encounter to its appointment, which must be a
+// scheduled booking for the same patient not yet checked in.
+func (s *EncounterService) applyAppointment(encounter *models.Encounter) error {
+	appointment, err := s.appointmentRepo.GetAppointmentById(*encounter.AppointmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("appointment not found")
+		}
+		return err
+	}
+	if appointment.PatientID != encounter.PatientID {
+		return errors.New("appointment not found")
+	}
+	if appointment.Status != models.AppointmentScheduled {
+		return errors.New("appointment is cancelled")
+	}
+
+	if _, err := s.encounterRepo.GetEncounterByAppointmentId(appointment.ID); err == nil {
+		return errors.New("appointment already checked in")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if encounter.DoctorID == 0 {
+		encounter.DoctorID = appointment.DoctorID
+	}
+	if encounter.Reason == "" {
+		encounter.Reason = appointment.Reason
+	}
+	return nil
+}
+
+func (s *EncounterService) checkDoctor(doctorID uint) error {
+	if doctorID == 0 {
+		return errors.New("doctor_id is required")
+	}
+
+	doctor, err := s.userRepo.GetUserByID(doctorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("doctor not found")
+		}
+		return err
+	}
+	if doctor.Role != models.Doc {
+		return errors.New("doctor not found")
+	}
+	return nil
+}
+
+func (s *EncounterService) getEncounter(patientID uint, encounterIdStr string) (*models.Encounter, error) {
+	encounterID, err := strconv.ParseUint(encounterIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid encounter ID")
+	}
+
+	encounter, err := s.encounterRepo.GetEncounterById(patientID, uint(encounterID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("encounter not found")
+		}
+		return nil, err
+	}
+	return encounter, nil
+}
+
+func linked(byID map[uint]*response.EncounterResponse, encounterID *uint) *response.EncounterResponse {
+	if encounterID == nil {
+		return nil
+	}
+	return byID[*encounterID]
+}
@@ -0,0 +1,205 @@
+package encounter_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	appointmentmocks "github.com/palashbhasme/healthcare-portal/internal/services/appointment_service/mocks"
+	conditionmocks "github.com/palashbhasme/healthcare-portal/internal/services/condition_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	labmocks "github.com/palashbhasme/healthcare-portal/internal/services/lab_service/mocks"
+	notemocks "github.com/palashbhasme/healthcare-portal/internal/services/note_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	prescriptionmocks "github.com/palashbhasme/healthcare-portal/internal/services/prescription_service/mocks"
+	vitalmocks "github.com/palashbhasme/healthcare-portal/internal/services/vital_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestCheckIn_FromAppointment(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, appointmentRepo, userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+	appointmentRepo.On("GetAppointmentById", uint(3)).
+		Return(&models.Appointment{ID: 3, PatientID: 1, DoctorID: 5, Reason: "Follow-up", Status: models.AppointmentScheduled}, nil)
+	encounterRepo.On("GetEncounterByAppointmentId", uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	encounterRepo.On("CreateEncounter", mock.MatchedBy(func(e *models.Encounter) bool {
+		return e.PatientID == 1 && e.DoctorID == 5 && *e.AppointmentID == 3 && e.Reason == "Follow-up" &&
+			e.Type == models.EncounterOffice && e.CreatedBy == "9"
+	})).Return(&models.Encounter{ID: 7, PatientID: 1, DoctorID: 5, AppointmentID: uintPtr(3)}, nil)
+
+	encounter, err := service.CheckIn("1", &request.EncounterRequest{AppointmentID: uintPtr(3)}, "receptionist", "9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), encounter.ID)
+}
+
+func TestCheckIn_AppointmentOnlyOnce(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, appointmentRepo, userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+	appointmentRepo.On("GetAppointmentById", uint(3)).
+		Return(&models.Appointment{ID: 3, PatientID: 1, DoctorID: 5, Status: models.AppointmentScheduled}, nil)
+	encounterRepo.On("GetEncounterByAppointmentId", uint(3)).Return(&models.Encounter{ID: 7}, nil)
+
+	_, err := service.CheckIn("1", &request.EncounterRequest{AppointmentID: uintPtr(3)}, "receptionist", "9")
+
+	assert.EqualError(t, err, "appointment already checked in")
+}
+
+func TestCheckIn_OtherPatientsAppointment(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(new(mocks.MockEncounterRepository), patientRepo, appointmentRepo, userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+	appointmentRepo.On("GetAppointmentById", uint(3)).
+		Return(&models.Appointment{ID: 3, PatientID: 2, DoctorID: 5, Status: models.AppointmentScheduled}, nil)
+
+	_, err := service.CheckIn("1", &request.EncounterRequest{AppointmentID: uintPtr(3)}, "receptionist", "9")
+
+	assert.EqualError(t, err, "appointment not found")
+}
+
+func TestCheckIn_WalkInDefaultsToCallingDoctor(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+	encounterRepo.On("CreateEncounter", mock.MatchedBy(func(e *models.Encounter) bool {
+		return e.DoctorID == 5 && e.AppointmentID == nil && e.Type == models.EncounterTelehealth
+	})).Return(&models.Encounter{ID: 8, PatientID: 1, DoctorID: 5}, nil)
+
+	_, err := service.CheckIn("1", &request.EncounterRequest{Type: "telehealth"}, "doctor", "5")
+
+	assert.NoError(t, err)
+}
+
+func TestCheckIn_ReceptionistMustNameDoctor(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(new(mocks.MockEncounterRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+
+	_, err := service.CheckIn("1", &request.EncounterRequest{}, "receptionist", "9")
+
+	assert.EqualError(t, err, "doctor_id is required")
+}
+
+func TestCheckOut_AlreadyCheckedOut(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), userRepo,
+		new(vitalmocks.MockVitalRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), new(labmocks.MockLabRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+
+	checkedOut := time.Now().Add(-time.Hour)
+	encounterRepo.On("GetEncounterById", uint(1), uint(7)).Return(&models.Encounter{ID: 7, PatientID: 1, CheckOutAt: &checkedOut}, nil)
+
+	_, err := service.CheckOut("1", "7", "receptionist")
+
+	assert.EqualError(t, err, "encounter already checked out")
+}
+
+func TestListEncounters_NestsClinicalDataForDoctors(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	vitalRepo := new(vitalmocks.MockVitalRepository)
+	conditionRepo := new(conditionmocks.MockConditionRepository)
+	prescriptionRepo := new(prescriptionmocks.MockPrescriptionRepository)
+	noteRepo := new(notemocks.MockNoteRepository)
+	labRepo := new(labmocks.MockLabRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), userRepo,
+		vitalRepo, conditionRepo, prescriptionRepo, noteRepo, labRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+
+	weight := 80.0
+	encounterRepo.On("ListEncounters", uint(1)).Return([]models.Encounter{{ID: 8, PatientID: 1}, {ID: 7, PatientID: 1}}, nil)
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{}).Return([]models.Vital{
+		{ID: 1, EncounterID: uintPtr(7), WeightKg: &weight},
+		{ID: 2},
+	}, nil)
+	conditionRepo.On("ListConditions", uint(1), models.ConditionStatus("")).Return([]models.Condition{{ID: 4, EncounterID: uintPtr(8)}}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), []models.PrescriptionStatus(nil)).Return([]models.Prescription{}, nil)
+	noteRepo.On("ListNotes", uint(1), repository.NoteFilter{}).Return([]models.ClinicalNote{
+		{ID: 3, EncounterID: 7}, {ID: 4, EncounterID: 7, AddendumTo: uintPtr(3)},
+	}, nil)
+	labRepo.On("ListLabOrders", uint(1), repository.LabOrderFilter{}).Return([]models.LabOrder{
+		{ID: 2, EncounterID: uintPtr(8), Results: []models.LabResult{{ID: 1, Flag: models.LabFlagHigh}}},
+	}, nil)
+
+	encounters, err := service.ListEncounters("1", "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, encounters, 2)
+	assert.Empty(t, encounters[0].Vitals)
+	assert.Len(t, encounters[0].Conditions, 1)
+	assert.Len(t, encounters[1].Vitals, 1)
+	assert.NotNil(t, encounters[1].Prescriptions)
+	assert.Empty(t, encounters[0].Notes)
+	assert.Len(t, encounters[1].Notes, 2)
+	assert.Len(t, encounters[0].LabOrders[0].Results, 1)
+}
+
+func TestListEncounters_ReceptionistSeesVisitsAndLabStatus(t *testing.T) {
+	encounterRepo := new(mocks.MockEncounterRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	userRepo := new(appointmentmocks.MockUserRepository)
+	vitalRepo := new(vitalmocks.MockVitalRepository)
+	labRepo := new(labmocks.MockLabRepository)
+	service := NewEncounterService(encounterRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), userRepo,
+		vitalRepo, new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository), new(notemocks.MockNoteRepository), labRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	userRepo.On("GetUserByID", uint(5)).Return(&models.User{ID: 5, Role: models.Doc}, nil)
+	encounterRepo.On("ListEncounters", uint(1)).Return([]models.Encounter{{ID: 7, PatientID: 1}}, nil)
+	labRepo.On("ListLabOrders", uint(1), repository.LabOrderFilter{}).Return([]models.LabOrder{
+		{ID: 2, EncounterID: uintPtr(7), Status: models.LabResulted, Results: []models.LabResult{{ID: 1}}},
+	}, nil)
+
+	encounters, err := service.ListEncounters("1", "receptionist")
+
+	assert.NoError(t, err)
+	assert.Len(t, encounters, 1)
+	assert.Nil(t, encounters[0].Vitals)
+	assert.Nil(t, encounters[0].Conditions)
+	assert.Nil(t, encounters[0].Prescriptions)
+	assert.Nil(t, encounters[0].Notes)
+	assert.Equal(t, "resulted", encounters[0].LabOrders[0].Status)
+	assert.Nil(t, encounters[0].LabOrders[0].Results)
+	vitalRepo.AssertNotCalled(t, "ListVitals", mock.Anything, mock.Anything)
+}
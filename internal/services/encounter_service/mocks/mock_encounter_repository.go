@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEncounterRepository struct {
+	mock.Mock
+}
+
+func (m *MockEncounterRepository) CreateEncounter(encounter *models.Encounter) (*models.Encounter, error) {
+	args := m.Called(encounter)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Encounter), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockEncounterRepository) GetEncounterById(patientID, id uint) (*models.Encounter, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Encounter), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockEncounterRepository) GetEncounterByAppointmentId(appointmentID uint) (*models.Encounter, error) {
+	args := m.Called(appointmentID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Encounter), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockEncounterRepository) ListEncounters(patientID uint) ([]models.Encounter, error) {
+	args := m.Called(patientID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Encounter), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockEncounterRepository) CheckOutEncounter(patientID, id uint, at time.Time) (*models.Encounter, error) {
+	args := m.Called(patientID, id, at)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Encounter), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// icd10Pattern matches an ICD-10-CM code in dotted form: a category letter,
+// two characters and an optional subcategory of up to four characters.
+var icd10Pattern = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z](\.[0-9A-Z]{1,4})?$`)
+
+// NormalizeICD10Code upper-cases code, inserts the dot after the category
+// when it is missing (CMS files list E119 for E11.9) and checks the result
+// has the shape of an ICD-10-CM code.
+func NormalizeICD10Code(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) > 3 && !strings.Contains(code, ".") {
+		code = code[:3] + "." + code[3:]
+	}
+	if !icd10Pattern.MatchString(code) {
+		return "", errors.New("invalid ICD-10 code")
+	}
+	return code, nil
+}
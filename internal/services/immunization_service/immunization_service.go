@@ -0,0 +1,94 @@
+package immunization_service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+)
+
+type ImmunizationService struct {
+	immunizationRepo repository.ImmunizationRepository
+	patientRepo      repository.PatientRepository
+	schedule         *ImmunizationSchedule
+}
+
+func NewImmunizationService(immunizationRepo repository.ImmunizationRepository,
+	patientRepo repository.PatientRepository,
+	schedule *ImmunizationSchedule) *ImmunizationService {
+	return &ImmunizationService{
+		immunizationRepo: immunizationRepo,
+		patientRepo:      patientRepo,
+		schedule:         schedule,
+	}
+}
+
+// RecordImmunization stores an administered dose. Codes of scheduled
+// vaccines are stored as the schedule spells them so the forecast finds them.
+func (s *ImmunizationService) RecordImmunization(patientIdStr string, immunizationRequest *request.ImmunizationRequest, role any, userID string) (*response.ImmunizationResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "record_immunization")
+	if err != nil {
+		return nil, err
+	}
+
+	immunization, err := mapper.ImmunizationToModel(patient.ID, immunizationRequest, userID)
+	if err != nil {
+		return nil, errors.New("invalid administered_on")
+	}
+	if immunization.AdministeredOn.After(dateOf(time.Now())) {
+		return nil, errors.New("administered_on is in the future")
+	}
+	if immunization.AdministeredOn.Before(dateOf(patient.DOB)) {
+		return nil, errors.New("administered_on is before date of birth")
+	}
+	if vaccine := s.schedule.Vaccine(immunization.VaccineCode); vaccine != nil {
+		immunization.VaccineCode = vaccine.Code
+	}
+
+	immunization, err = s.immunizationRepo.CreateImmunization(immunization)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ImmunizationToResponse(immunization), nil
+}
+
+func (s *ImmunizationService) ListImmunizations(patientIdStr string, role any) ([]*response.ImmunizationResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_immunization")
+	if err != nil {
+		return nil, err
+	}
+
+	immunizations, err := s.immunizationRepo.ListImmunizations(patient.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ImmunizationsToResponse(immunizations), nil
+}
+
+// GetForecast lists the next dose of every scheduled vaccine with its status
+// as of the query date. It shows no lot or administration details, so the
+// front desk may check it at check-in.
+func (s *ImmunizationService) GetForecast(patientIdStr string, query *request.ImmunizationForecastQuery, role any) (*response.ImmunizationForecastResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_immunization_forecast")
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := dateOf(time.Now())
+	if query.AsOf != "" {
+		asOf, err = time.Parse("2006-01-02", query.AsOf)
+		if err != nil {
+			return nil, errors.New("invalid as_of")
+		}
+	}
+
+	immunizations, err := s.immunizationRepo.ListImmunizations(patient.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ForecastToResponse(patient.ID, asOf, s.schedule.Forecast(patient.DOB, immunizations, asOf)), nil
+}
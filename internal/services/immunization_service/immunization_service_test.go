@@ -0,0 +1,106 @@
+package immunization_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/immunization_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordImmunization_UsesScheduleCode(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	immunizationRepo := new(mocks.MockImmunizationRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewImmunizationService(immunizationRepo, patientRepo, schedule)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: date(2025, 1, 15)}, nil)
+	immunizationRepo.On("CreateImmunization", mock.MatchedBy(func(i *models.Immunization) bool {
+		return i.PatientID == 1 && i.VaccineCode == "HepB" && i.DoseNumber == 1 &&
+			i.AdministeredOn.Equal(date(2025, 1, 15)) && i.Site == models.SiteLeftThigh && i.AdministeredBy == "5"
+	})).Return(&models.Immunization{ID: 3, VaccineCode: "HepB", AdministeredOn: date(2025, 1, 15)}, nil)
+
+	immunization, err := service.RecordImmunization("1", &request.ImmunizationRequest{
+		VaccineCode: "hepb", DoseNumber: 1, AdministeredOn: "2025-01-15", LotNumber: "HB123", Site: "left_thigh",
+	}, "doctor", "5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-15", immunization.AdministeredOn)
+}
+
+func TestRecordImmunization_BeforeBirth(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewImmunizationService(new(mocks.MockImmunizationRepository), patientRepo, schedule)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: date(2025, 1, 15)}, nil)
+
+	_, err = service.RecordImmunization("1", &request.ImmunizationRequest{
+		VaccineCode: "HepB", DoseNumber: 1, AdministeredOn: "2025-01-14", LotNumber: "HB123", Site: "left_thigh",
+	}, "doctor", "5")
+
+	assert.EqualError(t, err, "administered_on is before date of birth")
+}
+
+func TestRecordImmunization_InFuture(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewImmunizationService(new(mocks.MockImmunizationRepository), patientRepo, schedule)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: date(2025, 1, 15)}, nil)
+
+	_, err = service.RecordImmunization("1", &request.ImmunizationRequest{
+		VaccineCode: "HepB", DoseNumber: 1, AdministeredOn: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		LotNumber: "HB123", Site: "left_thigh",
+	}, "doctor", "5")
+
+	assert.EqualError(t, err, "administered_on is in the future")
+}
+
+func TestListImmunizations_ReceptionistDenied(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewImmunizationService(new(mocks.MockImmunizationRepository), patientRepo, schedule)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: date(2025, 1, 15)}, nil)
+
+	_, err = service.ListImmunizations("1", "receptionist")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestGetForecast_FrontDesk(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	immunizationRepo := new(mocks.MockImmunizationRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewImmunizationService(immunizationRepo, patientRepo, schedule)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: date(2025, 1, 15)}, nil)
+	immunizationRepo.On("ListImmunizations", uint(1)).Return([]models.Immunization{
+		{VaccineCode: "HepB", DoseNumber: 1, AdministeredOn: date(2025, 1, 15)},
+	}, nil)
+
+	forecast, err := service.GetForecast("1", &request.ImmunizationForecastQuery{AsOf: "2025-03-20"}, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-03-20", forecast.AsOf)
+	assert.Equal(t, "HepB", forecast.Items[0].VaccineCode)
+	assert.Equal(t, 2, forecast.Items[0].DoseNumber)
+	assert.Equal(t, "due", forecast.Items[0].Status)
+	assert.Equal(t, "2025-02-15", forecast.Items[0].DueDate)
+}
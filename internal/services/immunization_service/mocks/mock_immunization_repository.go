@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImmunizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockImmunizationRepository) CreateImmunization(immunization *models.Immunization) (*models.Immunization, error) {
+	args := m.Called(immunization)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Immunization), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockImmunizationRepository) ListImmunizations(patientID uint) ([]models.Immunization, error) {
+	args := m.Called(patientID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Immunization), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,183 @@
+package immunization_service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+)
+
+// bundledSchedule is the immunization schedule used when no file is
+// configured.
+//
+//go:embed immunization_schedule.json
+var bundledSchedule []byte
+
+// ImmunizationSchedule lists the vaccines forecast for every patient. Ages
+// are measured from the patient's date of birth.
+type ImmunizationSchedule struct {
+	Vaccines []ScheduledVaccine `json:"vaccines"`
+
+	// byCode maps a lower-cased vaccine code to its entry
+	byCode map[string]*ScheduledVaccine
+}
+
+// ScheduledVaccine is a vaccine series. Doses are not forecast once the
+// patient reaches MaxAgeMonths.
+type ScheduledVaccine struct {
+	Code         string          `json:"code"`
+	Name         string          `json:"name"`
+	MaxAgeMonths *int            `json:"max_age_months,omitempty"`
+	Doses        []ScheduledDose `json:"doses"`
+}
+
+// ScheduledDose is due at DueMonths of age, or MinIntervalDays after the
+// previous dose if that is later, and overdue at OverdueMonths, pushed back
+// by the same amount.
+type ScheduledDose struct {
+	Dose            int `json:"dose"`
+	DueMonths       int `json:"due_months"`
+	OverdueMonths   int `json:"overdue_months"`
+	MinIntervalDays int `json:"min_interval_days,omitempty"`
+}
+
+// LoadImmunizationSchedule reads the schedule from path, or the bundled
+// schedule when path is empty.
+func LoadImmunizationSchedule(path string) (*ImmunizationSchedule, error) {
+	if path == "" {
+		return ParseImmunizationSchedule(bytes.NewReader(bundledSchedule))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	schedule, err := ParseImmunizationSchedule(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schedule, nil
+}
+
+func ParseImmunizationSchedule(r io.Reader) (*ImmunizationSchedule, error) {
+	var schedule ImmunizationSchedule
+	if err := json.NewDecoder(r).Decode(&schedule); err != nil {
+		return nil, err
+	}
+
+	schedule.byCode = make(map[string]*ScheduledVaccine, len(schedule.Vaccines))
+	for i := range schedule.Vaccines {
+		vaccine := &schedule.Vaccines[i]
+		key := strings.ToLower(vaccine.Code)
+		if key == "" {
+			return nil, errors.New("vaccine without a code")
+		}
+		if _, ok := schedule.byCode[key]; ok {
+			return nil, fmt.Errorf("vaccine %s: listed twice", vaccine.Code)
+		}
+		if err := checkDoses(vaccine); err != nil {
+			return nil, fmt.Errorf("vaccine %s: %w", vaccine.Code, err)
+		}
+		schedule.byCode[key] = vaccine
+	}
+	return &schedule, nil
+}
+
+func checkDoses(vaccine *ScheduledVaccine) error {
+	if len(vaccine.Doses) == 0 {
+		return errors.New("no doses")
+	}
+	for i, dose := range vaccine.Doses {
+		if dose.Dose != i+1 {
+			return fmt.Errorf("dose %d listed as dose %d", i+1, dose.Dose)
+		}
+		if dose.DueMonths < 0 || dose.MinIntervalDays < 0 {
+			return fmt.Errorf("dose %d: negative age or interval", dose.Dose)
+		}
+		if dose.OverdueMonths <= dose.DueMonths {
+			return fmt.Errorf("dose %d: overdue_months must be after due_months", dose.Dose)
+		}
+		if i > 0 && dose.DueMonths < vaccine.Doses[i-1].DueMonths {
+			return fmt.Errorf("dose %d: due before dose %d", dose.Dose, i)
+		}
+	}
+	return nil
+}
+
+// Vaccine returns the scheduled vaccine with code, ignoring case, or nil.
+func (s *ImmunizationSchedule) Vaccine(code string) *ScheduledVaccine {
+	return s.byCode[strings.ToLower(strings.TrimSpace(code))]
+}
+
+// Forecast returns the next dose of every scheduled vaccine for a patient
+// born on dob, as of the given date. A series continues from the highest
+// dose number recorded for it.
+func (s *ImmunizationSchedule) Forecast(dob time.Time, immunizations []models.Immunization, asOf time.Time) []models.ForecastItem {
+	latest := make(map[string]*models.Immunization)
+	for i := range immunizations {
+		key := strings.ToLower(immunizations[i].VaccineCode)
+		if previous, ok := latest[key]; !ok || immunizations[i].DoseNumber >= previous.DoseNumber {
+			latest[key] = &immunizations[i]
+		}
+	}
+
+	dob, asOf = dateOf(dob), dateOf(asOf)
+	items := make([]models.ForecastItem, 0, len(s.Vaccines))
+	for i := range s.Vaccines {
+		vaccine := &s.Vaccines[i]
+		items = append(items, vaccine.forecast(dob, latest[strings.ToLower(vaccine.Code)], asOf))
+	}
+	return items
+}
+
+func (v *ScheduledVaccine) forecast(dob time.Time, last *models.Immunization, asOf time.Time) models.ForecastItem {
+	item := models.ForecastItem{VaccineCode: v.Code, VaccineName: v.Name, DoseNumber: 1}
+	if last != nil {
+		item.DoseNumber = last.DoseNumber + 1
+	}
+	if item.DoseNumber > len(v.Doses) {
+		item.DoseNumber = len(v.Doses)
+		item.Status = models.ForecastComplete
+		return item
+	}
+	if v.MaxAgeMonths != nil && !asOf.Before(dob.AddDate(0, *v.MaxAgeMonths, 0)) {
+		item.Status = models.ForecastAgedOut
+		return item
+	}
+
+	dose := v.Doses[item.DoseNumber-1]
+	due := dob.AddDate(0, dose.DueMonths, 0)
+	overdue := dob.AddDate(0, dose.OverdueMonths, 0)
+	if last != nil {
+		earliest := dateOf(last.AdministeredOn).AddDate(0, 0, dose.MinIntervalDays)
+		if earliest.After(due) {
+			overdue = overdue.Add(earliest.Sub(due))
+			due = earliest
+		}
+	}
+	item.DueDate, item.OverdueDate = &due, &overdue
+
+	switch {
+	case asOf.Before(due):
+		item.Status = models.ForecastUpcoming
+	case asOf.Before(overdue):
+		item.Status = models.ForecastDue
+	default:
+		item.Status = models.ForecastOverdue
+	}
+	return item
+}
+
+// dateOf drops the time of day, keeping the calendar date.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package immunization_service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func forecastFor(t *testing.T, items []models.ForecastItem, code string) models.ForecastItem {
+	for _, item := range items {
+		if item.VaccineCode == code {
+			return item
+		}
+	}
+	t.Fatalf("no forecast for %s", code)
+	return models.ForecastItem{}
+}
+
+func TestLoadImmunizationSchedule_Bundled(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, schedule.Vaccines)
+	assert.Equal(t, "DTaP", schedule.Vaccine(" dtap ").Code)
+	assert.Nil(t, schedule.Vaccine("YF"))
+}
+
+func TestParseImmunizationSchedule_RejectsBadDoses(t *testing.T) {
+	_, err := ParseImmunizationSchedule(strings.NewReader(`{"vaccines": [{"code": "MMR", "doses": [
+		{"dose": 1, "due_months": 12, "overdue_months": 12}
+	]}]}`))
+	assert.EqualError(t, err, "vaccine MMR: dose 1: overdue_months must be after due_months")
+
+	_, err = ParseImmunizationSchedule(strings.NewReader(`{"vaccines": [{"code": "MMR", "doses": [
+		{"dose": 2, "due_months": 12, "overdue_months": 16}
+	]}]}`))
+	assert.EqualError(t, err, "vaccine MMR: dose 1 listed as dose 2")
+}
+
+func TestForecast_Statuses(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	dob := date(2025, 1, 15)
+	given := []models.Immunization{
+		{VaccineCode: "HepB", DoseNumber: 1, AdministeredOn: date(2025, 1, 15)},
+	}
+
+	items := schedule.Forecast(dob, given, date(2025, 4, 20))
+
+	hepB := forecastFor(t, items, "HepB")
+	assert.Equal(t, 2, hepB.DoseNumber)
+	assert.Equal(t, models.ForecastOverdue, hepB.Status)
+	assert.Equal(t, date(2025, 2, 15), *hepB.DueDate)
+	assert.Equal(t, date(2025, 4, 15), *hepB.OverdueDate)
+	assert.Equal(t, models.ForecastDue, forecastFor(t, items, "RV").Status)
+	assert.Equal(t, models.ForecastUpcoming, forecastFor(t, items, "MMR").Status)
+}
+
+func TestForecast_MinimumInterval(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	given := []models.Immunization{
+		{VaccineCode: "DTaP", DoseNumber: 1, AdministeredOn: date(2025, 5, 1)},
+	}
+
+	dtap := forecastFor(t, schedule.Forecast(date(2025, 1, 15), given, date(2025, 5, 20)), "DTaP")
+
+	assert.Equal(t, 2, dtap.DoseNumber)
+	assert.Equal(t, models.ForecastUpcoming, dtap.Status)
+	assert.Equal(t, date(2025, 5, 29), *dtap.DueDate)
+	assert.Equal(t, date(2025, 6, 29), *dtap.OverdueDate)
+}
+
+func TestForecast_CompleteAndAgedOut(t *testing.T) {
+	schedule, err := LoadImmunizationSchedule("")
+	require.NoError(t, err)
+
+	given := []models.Immunization{
+		{VaccineCode: "MMR", DoseNumber: 1, AdministeredOn: date(2016, 1, 20)},
+		{VaccineCode: "MMR", DoseNumber: 2, AdministeredOn: date(2019, 1, 20)},
+	}
+
+	items := schedule.Forecast(date(2015, 1, 15), given, date(2025, 1, 1))
+
+	mmr := forecastFor(t, items, "MMR")
+	assert.Equal(t, models.ForecastComplete, mmr.Status)
+	assert.Nil(t, mmr.DueDate)
+	assert.Equal(t, models.ForecastAgedOut, forecastFor(t, items, "RV").Status)
+	assert.Equal(t, models.ForecastUpcoming, forecastFor(t, items, "Tdap").Status)
+}
@@ -0,0 +1,256 @@
+package lab_service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type LabService struct {
+	labRepo       repository.LabRepository
+	patientRepo   repository.PatientRepository
+	encounterRepo repository.EncounterRepository
+}
+
+func NewLabService(labRepo repository.LabRepository,
+	patientRepo repository.PatientRepository,
+	encounterRepo repository.EncounterRepository) *LabService {
+	return &LabService{
+		labRepo:       labRepo,
+		patientRepo:   patientRepo,
+		encounterRepo: encounterRepo,
+	}
+}
+
+// CreateLabOrder orders a test on behalf of the calling doctor, who is
+// alerted to any critical results.
+func (s *LabService) CreateLabOrder(patientIdStr string, orderRequest *request.LabOrderRequest, role any, userID string) (*response.LabOrderResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "order_lab")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	doctorID, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	order := mapper.LabOrderToModel(patientID, orderRequest, uint(doctorID))
+	if err := services.CheckEncounter(s.encounterRepo, patientID, order.EncounterID); err != nil {
+		return nil, err
+	}
+
+	order, err = s.labRepo.CreateLabOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.LabOrderToResponse(order, true), nil
+}
+
+// ListLabOrders returns the patient's orders, newest first. Roles without
+// view_lab see each order's status but not its results.
+func (s *LabService) ListLabOrders(patientIdStr string, query *request.LabOrderQuery, role any) ([]*response.LabOrderResponse, error) {
+	patientID, includeResults, err := s.authorizeView(patientIdStr, role)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.labRepo.ListLabOrders(patientID, repository.LabOrderFilter{Status: models.LabOrderStatus(query.Status)})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.LabOrdersToResponse(orders, includeResults), nil
+}
+
+func (s *LabService) GetLabOrderById(patientIdStr, orderIdStr string, role any) (*response.LabOrderResponse, error) {
+	patientID, includeResults, err := s.authorizeView(patientIdStr, role)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.getLabOrder(patientID, orderIdStr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.LabOrderToResponse(order, includeResults), nil
+}
+
+func (s *LabService) CollectLabOrder(patientIdStr, orderIdStr string, role any, userID string) (*response.LabOrderResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_lab")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	return s.transition(patientID, orderIdStr, models.LabCollected, map[string]interface{}{
+		"collected_by": userID,
+		"collected_at": time.Now(),
+	})
+}
+
+func (s *LabService) CancelLabOrder(patientIdStr, orderIdStr string, cancelRequest *request.CancelLabOrderRequest, role any, userID string) (*response.LabOrderResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_lab")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	return s.transition(patientID, orderIdStr, models.LabCancelled, map[string]interface{}{
+		"cancelled_by":  userID,
+		"cancelled_at":  time.Now(),
+		"cancel_reason": strings.TrimSpace(cancelRequest.Reason),
+	})
+}
+
+// RecordLabResults files the results of a collected order. Critical results
+// put an item on the ordering doctor's worklist in the same write.
+func (s *LabService) RecordLabResults(patientIdStr, orderIdStr string, resultsRequest *request.LabResultsRequest, role any, userID string) (*response.LabOrderResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "manage_lab")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	order, err := s.getLabOrder(patientID, orderIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanTransitionTo(models.LabResulted) {
+		return nil, errors.New("invalid status transition")
+	}
+
+	results := mapper.LabResultsToModel(patientID, resultsRequest)
+	for i := range results {
+		if err := validateLabResult(&results[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var alert *models.WorklistItem
+	if summary := summarizeCritical(order, results); summary != "" {
+		alert = &models.WorklistItem{
+			DoctorID:  order.OrderingDoctorID,
+			PatientID: patientID,
+			Kind:      models.WorklistCriticalLab,
+			Summary:   summary,
+			Status:    models.WorklistOpen,
+		}
+	}
+
+	order, err = s.labRepo.RecordLabResults(patientID, order.ID, results, map[string]interface{}{
+		"resulted_by": userID,
+		"resulted_at": time.Now(),
+	}, alert)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid status transition")
+		}
+		return nil, err
+	}
+	return mapper.LabOrderToResponse(order, true), nil
+}
+
+// transition moves the order to next, applying updates alongside the status
+// change. The write is conditional on the status read, so two concurrent
+// transitions cannot both succeed.
+func (s *LabService) transition(patientID uint, orderIdStr string, next models.LabOrderStatus, updates map[string]interface{}) (*response.LabOrderResponse, error) {
+	order, err := s.getLabOrder(patientID, orderIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanTransitionTo(next) {
+		return nil, errors.New("invalid status transition")
+	}
+
+	updates["status"] = next
+	updated, err := s.labRepo.UpdateLabOrderById(patientID, order.ID, []models.LabOrderStatus{order.Status}, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid status transition")
+		}
+		return nil, err
+	}
+	updated.Results = order.Results
+	return mapper.LabOrderToResponse(updated, true), nil
+}
+
+func (s *LabService) getLabOrder(patientID uint, orderIdStr string) (*models.LabOrder, error) {
+	orderID, err := strconv.ParseUint(orderIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid lab order ID")
+	}
+
+	order, err := s.labRepo.GetLabOrderById(patientID, uint(orderID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("lab order not found")
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// authorizeView lets roles with view_lab see results and roles with only
+// view_lab_status see order status, reporting which applies.
+func (s *LabService) authorizeView(patientIdStr string, role any) (uint, bool, error) {
+	includeResults := false
+	permission := "view_lab_status"
+	if roleValue, ok := role.(string); ok && services.CheckPermission(roleValue, "view_lab") == nil {
+		includeResults = true
+		permission = "view_lab"
+	}
+
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, permission)
+	if err != nil {
+		return 0, false, err
+	}
+	return patient.ID, includeResults, nil
+}
+
+func validateLabResult(result *models.LabResult) error {
+	if result.Value == nil && result.ValueText == "" {
+		return errors.New("result needs a value")
+	}
+	if result.ReferenceLow != nil && result.ReferenceHigh != nil && *result.ReferenceLow > *result.ReferenceHigh {
+		return errors.New("invalid reference range")
+	}
+	if result.CriticalLow != nil && result.CriticalHigh != nil && *result.CriticalLow >= *result.CriticalHigh {
+		return errors.New("invalid critical range")
+	}
+	return nil
+}
+
+// summarizeCritical describes the critical results for the worklist, for
+// example "Critical lab results for K: potassium 6.8 mmol/L (critical
+// high)". It returns "" when none are critical.
+func summarizeCritical(order *models.LabOrder, results []models.LabResult) string {
+	var critical []string
+	for _, result := range results {
+		if !result.Flag.IsCritical() {
+			continue
+		}
+		value := result.ValueText
+		if result.Value != nil {
+			value = strconv.FormatFloat(*result.Value, 'f', -1, 64)
+		}
+		if result.Unit != "" {
+			value += " " + result.Unit
+		}
+		critical = append(critical, fmt.Sprintf("%s %s (%s)", result.Analyte, value, strings.ReplaceAll(string(result.Flag), "_", " ")))
+	}
+	if len(critical) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Critical lab results for %s: %s", order.TestCode, strings.Join(critical, ", "))
+}
@@ -0,0 +1,205 @@
+package lab_service
+
+import (
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	encountermocks "github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/lab_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func potassium(value float64) request.LabResultRequest {
+	return request.LabResultRequest{
+		Analyte: "Potassium", Value: &value, Unit: "mmol/L",
+		ReferenceLow: floatPtr(3.5), ReferenceHigh: floatPtr(5.1),
+		CriticalLow: floatPtr(2.5), CriticalHigh: floatPtr(6.5),
+	}
+}
+
+func TestCreateLabOrder_OrderedByCallingDoctor(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("CreateLabOrder", mock.MatchedBy(func(o *models.LabOrder) bool {
+		return o.PatientID == 1 && o.TestCode == "BMP" && o.Priority == models.LabRoutine &&
+			o.OrderingDoctorID == 5 && o.Status == models.LabOrdered
+	})).Return(&models.LabOrder{ID: 2, PatientID: 1, TestCode: "BMP", Status: models.LabOrdered}, nil)
+
+	order, err := service.CreateLabOrder("1", &request.LabOrderRequest{TestCode: " bmp "}, "doctor", "5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), order.ID)
+}
+
+func TestCreateLabOrder_UnknownEncounter(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	encounterRepo := new(encountermocks.MockEncounterRepository)
+	service := NewLabService(new(mocks.MockLabRepository), patientRepo, encounterRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	encounterID := uint(7)
+	encounterRepo.On("GetEncounterById", uint(1), uint(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.CreateLabOrder("1", &request.LabOrderRequest{TestCode: "BMP", EncounterID: &encounterID}, "doctor", "5")
+
+	assert.EqualError(t, err, "encounter not found")
+}
+
+func TestCreateLabOrder_ReceptionistDenied(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(new(mocks.MockLabRepository), patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	_, err := service.CreateLabOrder("1", &request.LabOrderRequest{TestCode: "BMP"}, "receptionist", "9")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListLabOrders_ReceptionistSeesStatusOnly(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("ListLabOrders", uint(1), repository.LabOrderFilter{}).Return([]models.LabOrder{
+		{ID: 2, Status: models.LabResulted, Results: []models.LabResult{{ID: 1, Value: floatPtr(6.8), Flag: models.LabFlagCriticalHigh}}},
+	}, nil)
+
+	orders, err := service.ListLabOrders("1", &request.LabOrderQuery{}, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "resulted", orders[0].Status)
+	assert.Nil(t, orders[0].Results)
+}
+
+func TestListLabOrders_DoctorSeesResults(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("ListLabOrders", uint(1), repository.LabOrderFilter{Status: models.LabResulted}).Return([]models.LabOrder{
+		{ID: 2, Status: models.LabResulted, Results: []models.LabResult{{ID: 1, Value: floatPtr(6.8), Flag: models.LabFlagCriticalHigh}}},
+	}, nil)
+
+	orders, err := service.ListLabOrders("1", &request.LabOrderQuery{Status: "resulted"}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, orders[0].Results, 1)
+	assert.Equal(t, "critical_high", orders[0].Results[0].Flag)
+}
+
+func TestCollectLabOrder_InvalidTransition(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).Return(&models.LabOrder{ID: 2, Status: models.LabCancelled}, nil)
+
+	_, err := service.CollectLabOrder("1", "2", "doctor", "5")
+
+	assert.EqualError(t, err, "invalid status transition")
+}
+
+func TestRecordLabResults_CriticalAlertsOrderingDoctor(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).
+		Return(&models.LabOrder{ID: 2, PatientID: 1, TestCode: "BMP", OrderingDoctorID: 5, Status: models.LabCollected}, nil)
+	labRepo.On("RecordLabResults", uint(1), uint(2), mock.MatchedBy(func(results []models.LabResult) bool {
+		return len(results) == 2 && results[0].Flag == models.LabFlagCriticalHigh && results[1].Flag == models.LabFlagNormal
+	}), mock.Anything, mock.MatchedBy(func(alert *models.WorklistItem) bool {
+		return alert != nil && alert.DoctorID == 5 && alert.PatientID == 1 && alert.Kind == models.WorklistCriticalLab &&
+			alert.Summary == "Critical lab results for BMP: Potassium 6.8 mmol/L (critical high)"
+	})).Return(&models.LabOrder{ID: 2, Status: models.LabResulted}, nil)
+
+	order, err := service.RecordLabResults("1", "2", &request.LabResultsRequest{
+		Results: []request.LabResultRequest{potassium(6.8), potassium(4.2)},
+	}, "doctor", "6")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "resulted", order.Status)
+}
+
+func TestRecordLabResults_NoAlertWithoutCritical(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).
+		Return(&models.LabOrder{ID: 2, PatientID: 1, OrderingDoctorID: 5, Status: models.LabCollected}, nil)
+	labRepo.On("RecordLabResults", uint(1), uint(2), mock.Anything, mock.Anything, (*models.WorklistItem)(nil)).
+		Return(&models.LabOrder{ID: 2, Status: models.LabResulted}, nil)
+
+	_, err := service.RecordLabResults("1", "2", &request.LabResultsRequest{
+		Results: []request.LabResultRequest{potassium(5.8)},
+	}, "doctor", "5")
+
+	assert.NoError(t, err)
+}
+
+func TestRecordLabResults_LabFlagOverridesRanges(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).
+		Return(&models.LabOrder{ID: 2, PatientID: 1, TestCode: "BCX", OrderingDoctorID: 5, Status: models.LabCollected}, nil)
+	labRepo.On("RecordLabResults", uint(1), uint(2), mock.Anything, mock.Anything, mock.MatchedBy(func(alert *models.WorklistItem) bool {
+		return alert != nil && alert.Summary == "Critical lab results for BCX: Blood culture positive (critical high)"
+	})).Return(&models.LabOrder{ID: 2, Status: models.LabResulted}, nil)
+
+	_, err := service.RecordLabResults("1", "2", &request.LabResultsRequest{
+		Results: []request.LabResultRequest{{Analyte: "Blood culture", ValueText: "positive", Flag: "critical_high"}},
+	}, "doctor", "5")
+
+	assert.NoError(t, err)
+}
+
+func TestRecordLabResults_NotCollected(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).Return(&models.LabOrder{ID: 2, Status: models.LabOrdered}, nil)
+
+	_, err := service.RecordLabResults("1", "2", &request.LabResultsRequest{
+		Results: []request.LabResultRequest{potassium(4.2)},
+	}, "doctor", "5")
+
+	assert.EqualError(t, err, "invalid status transition")
+}
+
+func TestRecordLabResults_MissingValue(t *testing.T) {
+	labRepo := new(mocks.MockLabRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewLabService(labRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	labRepo.On("GetLabOrderById", uint(1), uint(2)).Return(&models.LabOrder{ID: 2, Status: models.LabCollected}, nil)
+
+	_, err := service.RecordLabResults("1", "2", &request.LabResultsRequest{
+		Results: []request.LabResultRequest{{Analyte: "Potassium"}},
+	}, "doctor", "5")
+
+	assert.EqualError(t, err, "result needs a value")
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLabRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabRepository) CreateLabOrder(order *models.LabOrder) (*models.LabOrder, error) {
+	args := m.Called(order)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.LabOrder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabRepository) GetLabOrderById(patientID, id uint) (*models.LabOrder, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.LabOrder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabRepository) ListLabOrders(patientID uint, filter repository.LabOrderFilter) ([]models.LabOrder, error) {
+	args := m.Called(patientID, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.LabOrder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabRepository) UpdateLabOrderById(patientID, id uint, fromStatuses []models.LabOrderStatus, updates map[string]interface{}) (*models.LabOrder, error) {
+	args := m.Called(patientID, id, fromStatuses, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.LabOrder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabRepository) RecordLabResults(patientID, id uint, results []models.LabResult, updates map[string]interface{}, alert *models.WorklistItem) (*models.LabOrder, error) {
+	args := m.Called(patientID, id, results, updates, alert)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.LabOrder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
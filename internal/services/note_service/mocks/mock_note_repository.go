@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockNoteRepository) CreateNote(note *models.ClinicalNote) (*models.ClinicalNote, error) {
+	args := m.Called(note)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ClinicalNote), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNoteRepository) GetNoteById(patientID, id uint) (*models.ClinicalNote, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ClinicalNote), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNoteRepository) ListNotes(patientID uint, filter repository.NoteFilter) ([]models.ClinicalNote, error) {
+	args := m.Called(patientID, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.ClinicalNote), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNoteRepository) UpdateDraftNoteById(patientID, id uint, updates map[string]interface{}) (*models.ClinicalNote, error) {
+	args := m.Called(patientID, id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ClinicalNote), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
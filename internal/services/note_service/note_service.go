@@ -0,0 +1,280 @@
+package note_service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type NoteService struct {
+	noteRepo      repository.NoteRepository
+	patientRepo   repository.PatientRepository
+	encounterRepo repository.EncounterRepository
+}
+
+func NewNoteService(noteRepo repository.NoteRepository,
+	patientRepo repository.PatientRepository,
+	encounterRepo repository.EncounterRepository) *NoteService {
+	return &NoteService{
+		noteRepo:      noteRepo,
+		patientRepo:   patientRepo,
+		encounterRepo: encounterRepo,
+	}
+}
+
+// CreateNote starts a draft note on the encounter. Notes may still be
+// written after the patient has checked out.
+func (s *NoteService) CreateNote(patientIdStr, encounterIdStr string, noteRequest *request.NoteRequest, role any, userID string) (*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "write_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounterID, err := s.getEncounterID(patientID, encounterIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	note := mapper.NoteToModel(patientID, encounterID, noteRequest, userID)
+	if note.IsEmpty() {
+		return nil, errors.New("note is empty")
+	}
+
+	note, err = s.noteRepo.CreateNote(note)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.NoteToResponse(note), nil
+}
+
+// ListEncounterNotes returns the notes and addenda written during the
+// encounter, oldest first.
+func (s *NoteService) ListEncounterNotes(patientIdStr, encounterIdStr string, role any) ([]*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	encounterID, err := s.getEncounterID(patientID, encounterIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := s.noteRepo.ListNotes(patientID, repository.NoteFilter{EncounterID: encounterID})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.NotesToResponse(notes), nil
+}
+
+func (s *NoteService) GetNoteById(patientIdStr, noteIdStr string, role any) (*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	note, err := s.getNote(patientID, noteIdStr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.NoteToResponse(note), nil
+}
+
+// UpdateNoteById edits a draft. Only the author may edit it, and signed notes
+// cannot be changed.
+func (s *NoteService) UpdateNoteById(patientIdStr, noteIdStr string, updateRequest *request.NoteUpdateRequest, role any, userID string) (*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "write_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	note, err := s.getDraftByAuthor(patientID, noteIdStr, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := mapper.NoteUpdateToColumns(updateRequest)
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	edited := *note
+	applySections(&edited, updates)
+	if edited.IsEmpty() {
+		return nil, errors.New("note is empty")
+	}
+
+	note, err = s.noteRepo.UpdateDraftNoteById(patientID, note.ID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("note is signed")
+		}
+		return nil, err
+	}
+	return mapper.NoteToResponse(note), nil
+}
+
+// SignNote locks the author's draft. After signing, the note can only be
+// corrected with an addendum.
+func (s *NoteService) SignNote(patientIdStr, noteIdStr string, role any, userID string) (*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "sign_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	note, err := s.getDraftByAuthor(patientID, noteIdStr, userID)
+	if err != nil {
+		return nil, err
+	}
+	if note.IsEmpty() {
+		return nil, errors.New("note is empty")
+	}
+
+	note, err = s.noteRepo.UpdateDraftNoteById(patientID, note.ID, map[string]interface{}{
+		"status":    models.NoteSigned,
+		"signed_by": userID,
+		"signed_at": time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("note is signed")
+		}
+		return nil, err
+	}
+	return mapper.NoteToResponse(note), nil
+}
+
+// AddAddendum starts a draft addendum to a signed note. Addenda always
+// reference the original note, never another addendum, and are signed like
+// any other note.
+func (s *NoteService) AddAddendum(patientIdStr, noteIdStr string, noteRequest *request.NoteRequest, role any, userID string) (*response.NoteResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "write_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	original, err := s.getNote(patientID, noteIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if original.AddendumTo != nil {
+		return nil, errors.New("addenda must reference the original note")
+	}
+	if original.Status != models.NoteSigned {
+		return nil, errors.New("only signed notes take addenda")
+	}
+
+	addendum := mapper.NoteToModel(patientID, original.EncounterID, noteRequest, userID)
+	addendum.AddendumTo = &original.ID
+	if addendum.IsEmpty() {
+		return nil, errors.New("note is empty")
+	}
+
+	addendum, err = s.noteRepo.CreateNote(addendum)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.NoteToResponse(addendum), nil
+}
+
+// GetNoteHistory returns the original note and every addendum to it. Asking
+// for an addendum returns the history of the note it amends.
+func (s *NoteService) GetNoteHistory(patientIdStr, noteIdStr string, role any) (*response.NoteHistoryResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_note")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	note, err := s.getNote(patientID, noteIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if note.AddendumTo != nil {
+		note, err = s.noteRepo.GetNoteById(patientID, *note.AddendumTo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addenda, err := s.noteRepo.ListNotes(patientID, repository.NoteFilter{AddendumTo: note.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &response.NoteHistoryResponse{
+		Note:    mapper.NoteToResponse(note),
+		Addenda: mapper.NotesToResponse(addenda),
+	}, nil
+}
+
+func (s *NoteService) getDraftByAuthor(patientID uint, noteIdStr, userID string) (*models.ClinicalNote, error) {
+	note, err := s.getNote(patientID, noteIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if note.Status != models.NoteDraft {
+		return nil, errors.New("note is signed")
+	}
+	if note.AuthorID != userID {
+		return nil, errors.New("permission denied")
+	}
+	return note, nil
+}
+
+func (s *NoteService) getNote(patientID uint, noteIdStr string) (*models.ClinicalNote, error) {
+	noteID, err := strconv.ParseUint(noteIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid note ID")
+	}
+
+	note, err := s.noteRepo.GetNoteById(patientID, uint(noteID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("note not found")
+		}
+		return nil, err
+	}
+	return note, nil
+}
+
+func (s *NoteService) getEncounterID(patientID uint, encounterIdStr string) (uint, error) {
+	encounterID, err := strconv.ParseUint(encounterIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid encounter ID")
+	}
+
+	id := uint(encounterID)
+	if err := services.CheckEncounter(s.encounterRepo, patientID, &id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// applySections copies the section updates onto note.
+func applySections(note *models.ClinicalNote, updates map[string]interface{}) {
+	sections := map[string]*string{
+		"subjective": &note.Subjective,
+		"objective":  &note.Objective,
+		"assessment": &note.Assessment,
+		"plan":       &note.Plan,
+	}
+	for column, value := range updates {
+		if section, ok := sections[column]; ok {
+			*section = value.(string)
+		}
+	}
+}
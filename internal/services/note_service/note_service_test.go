@@ -0,0 +1,222 @@
+package note_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	encountermocks "github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/note_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestCreateNote_StartsDraft(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	encounterRepo := new(encountermocks.MockEncounterRepository)
+	service := NewNoteService(noteRepo, patientRepo, encounterRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	encounterRepo.On("GetEncounterById", uint(1), uint(7)).Return(&models.Encounter{ID: 7, PatientID: 1}, nil)
+	noteRepo.On("CreateNote", mock.MatchedBy(func(n *models.ClinicalNote) bool {
+		return n.PatientID == 1 && n.EncounterID == 7 && n.Status == models.NoteDraft &&
+			n.AuthorID == "5" && n.Subjective == "Cough for 3 days"
+	})).Return(&models.ClinicalNote{ID: 3, PatientID: 1, EncounterID: 7, Status: models.NoteDraft}, nil)
+
+	note, err := service.CreateNote("1", "7", &request.NoteRequest{Subjective: " Cough for 3 days "}, "doctor", "5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), note.ID)
+}
+
+func TestCreateNote_Empty(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	encounterRepo := new(encountermocks.MockEncounterRepository)
+	service := NewNoteService(new(mocks.MockNoteRepository), patientRepo, encounterRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	encounterRepo.On("GetEncounterById", uint(1), uint(7)).Return(&models.Encounter{ID: 7, PatientID: 1}, nil)
+
+	_, err := service.CreateNote("1", "7", &request.NoteRequest{Plan: "  "}, "doctor", "5")
+
+	assert.EqualError(t, err, "note is empty")
+}
+
+func TestCreateNote_UnknownEncounter(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	encounterRepo := new(encountermocks.MockEncounterRepository)
+	service := NewNoteService(new(mocks.MockNoteRepository), patientRepo, encounterRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	encounterRepo.On("GetEncounterById", uint(1), uint(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.CreateNote("1", "7", &request.NoteRequest{Plan: "Rest"}, "doctor", "5")
+
+	assert.EqualError(t, err, "encounter not found")
+}
+
+func TestCreateNote_ReceptionistDenied(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(new(mocks.MockNoteRepository), patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	_, err := service.CreateNote("1", "7", &request.NoteRequest{Plan: "Rest"}, "receptionist", "9")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestUpdateNote_SignedNoteIsImmutable(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteSigned, AuthorID: "5", Plan: "Rest"}, nil)
+	plan := "Antibiotics"
+
+	_, err := service.UpdateNoteById("1", "3", &request.NoteUpdateRequest{Plan: &plan}, "doctor", "5")
+
+	assert.EqualError(t, err, "note is signed")
+	noteRepo.AssertNotCalled(t, "UpdateDraftNoteById", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateNote_OnlyAuthor(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteDraft, AuthorID: "5", Plan: "Rest"}, nil)
+	plan := "Antibiotics"
+
+	_, err := service.UpdateNoteById("1", "3", &request.NoteUpdateRequest{Plan: &plan}, "doctor", "6")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestUpdateNote_CannotBlankNote(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteDraft, AuthorID: "5", Plan: "Rest"}, nil)
+	plan := ""
+
+	_, err := service.UpdateNoteById("1", "3", &request.NoteUpdateRequest{Plan: &plan}, "doctor", "5")
+
+	assert.EqualError(t, err, "note is empty")
+}
+
+func TestSignNote_SignedConcurrently(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteDraft, AuthorID: "5", Plan: "Rest"}, nil)
+	noteRepo.On("UpdateDraftNoteById", uint(1), uint(3), mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.SignNote("1", "3", "doctor", "5")
+
+	assert.EqualError(t, err, "note is signed")
+}
+
+func TestSignNote_RecordsSigner(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	signedAt := time.Now()
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteDraft, AuthorID: "5", Plan: "Rest"}, nil)
+	noteRepo.On("UpdateDraftNoteById", uint(1), uint(3), mock.MatchedBy(func(u map[string]interface{}) bool {
+		return u["status"] == models.NoteSigned && u["signed_by"] == "5" && u["signed_at"] != nil
+	})).Return(&models.ClinicalNote{ID: 3, Status: models.NoteSigned, SignedBy: "5", SignedAt: &signedAt}, nil)
+
+	note, err := service.SignNote("1", "3", "doctor", "5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "signed", note.Status)
+}
+
+func TestAddAddendum_ReferencesOriginal(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, EncounterID: 7, Status: models.NoteSigned, AuthorID: "5"}, nil)
+	noteRepo.On("CreateNote", mock.MatchedBy(func(n *models.ClinicalNote) bool {
+		return n.AddendumTo != nil && *n.AddendumTo == 3 && n.EncounterID == 7 &&
+			n.Status == models.NoteDraft && n.AuthorID == "6"
+	})).Return(&models.ClinicalNote{ID: 4, AddendumTo: uintPtr(3)}, nil)
+
+	addendum, err := service.AddAddendum("1", "3", &request.NoteRequest{Assessment: "Corrected dose"}, "doctor", "6")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), *addendum.AddendumTo)
+}
+
+func TestAddAddendum_DraftNote(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteDraft}, nil)
+
+	_, err := service.AddAddendum("1", "3", &request.NoteRequest{Plan: "Rest"}, "doctor", "5")
+
+	assert.EqualError(t, err, "only signed notes take addenda")
+}
+
+func TestAddAddendum_ToAddendum(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(4)).
+		Return(&models.ClinicalNote{ID: 4, PatientID: 1, Status: models.NoteSigned, AddendumTo: uintPtr(3)}, nil)
+
+	_, err := service.AddAddendum("1", "4", &request.NoteRequest{Plan: "Rest"}, "doctor", "5")
+
+	assert.EqualError(t, err, "addenda must reference the original note")
+}
+
+func TestGetNoteHistory_FromAddendum(t *testing.T) {
+	noteRepo := new(mocks.MockNoteRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewNoteService(noteRepo, patientRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(4)).
+		Return(&models.ClinicalNote{ID: 4, PatientID: 1, AddendumTo: uintPtr(3)}, nil)
+	noteRepo.On("GetNoteById", uint(1), uint(3)).
+		Return(&models.ClinicalNote{ID: 3, PatientID: 1, Status: models.NoteSigned}, nil)
+	noteRepo.On("ListNotes", uint(1), repository.NoteFilter{AddendumTo: 3}).
+		Return([]models.ClinicalNote{{ID: 4, AddendumTo: uintPtr(3)}, {ID: 5, AddendumTo: uintPtr(3)}}, nil)
+
+	history, err := service.GetNoteHistory("1", "4", "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), history.Note.ID)
+	assert.Len(t, history.Addenda, 2)
+}
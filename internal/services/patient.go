@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// AuthorizePatient checks that role holds permission and returns the patient
+// identified by patientIdStr, for services that manage records a patient
+// owns.
+func AuthorizePatient(patientRepo repository.PatientRepository, patientIdStr string, role any, permission string) (*models.Patient, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := CheckPermission(roleValue, permission); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(patientIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	patient, err := patientRepo.GetPatientById(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		return nil, err
+	}
+	return patient, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+)
+
+// Patient responses are built here rather than in the mapper so that every
+// one of them passes through the role's field access. Attributes are copied
+// by name, so one added to the model stays hidden until it is listed here.
+
+// PatientToResponse maps a patient for the given role, leaving out every
+// attribute the role is not allowed to read.
+func PatientToResponse(patient *models.Patient, role string) *response.PatientResponse {
+	patientResponse := &response.PatientResponse{ID: patient.ID, Version: patient.Version}
+	if patient.DeletedAt.Valid {
+		archivedAt := patient.DeletedAt.Time
+		patientResponse.ArchivedAt = &archivedAt
+	}
+
+	if CanReadField(role, "first_name") {
+		patientResponse.FirstName = patient.FirstName
+	}
+	if CanReadField(role, "last_name") {
+		patientResponse.LastName = patient.LastName
+	}
+	if CanReadField(role, "email") {
+		patientResponse.Email = patient.Email
+	}
+	if CanReadField(role, "gender") {
+		patientResponse.Gender = patient.Gender
+	}
+	if CanReadField(role, "phone_number") {
+		patientResponse.PhoneNumber = patient.PhoneNumber
+	}
+	if CanReadField(role, "address") {
+		patientResponse.Address = patient.Address
+	}
+	if CanReadField(role, "medical_history") {
+		medicalHistory := patient.MedicalHistory
+		patientResponse.MedicalHistory = &medicalHistory
+	}
+	if CanReadField(role, "dob") {
+		dob := patient.DOB
+		patientResponse.DOB = &dob
+	}
+	return patientResponse
+}
+
+func PatientsToResponse(patients []models.Patient, role string) []*response.PatientResponse {
+	patientResponses := make([]*response.PatientResponse, 0, len(patients))
+	for i := range patients {
+		patientResponses = append(patientResponses, PatientToResponse(&patients[i], role))
+	}
+	return patientResponses
+}
+
+// PatientVersionsToResponse drops changes to attributes the role may not read.
+func PatientVersionsToResponse(versions []models.PatientVersion, role string) []*response.PatientVersionResponse {
+	versionResponses := make([]*response.PatientVersionResponse, 0, len(versions))
+	for _, v := range versions {
+		changes := make([]*response.FieldChangeResponse, 0, len(v.Changes))
+		for _, c := range v.Changes {
+			if !CanReadField(role, c.Field) {
+				continue
+			}
+			changes = append(changes, &response.FieldChangeResponse{Field: c.Field, Old: c.Old, New: c.New})
+		}
+		versionResponses = append(versionResponses, &response.PatientVersionResponse{
+			Version:    v.Version,
+			ChangeType: string(v.ChangeType),
+			ChangedBy:  v.ChangedBy,
+			ChangedAt:  v.CreatedAt,
+			Changes:    changes,
+		})
+	}
+	return versionResponses
+}
+
+// PatientToDocument renders the patient attributes the role may read in the
+// same shape as PatientUpdateRequest. It is the document patches apply to.
+func PatientToDocument(patient *models.Patient, role string) map[string]interface{} {
+	values := map[string]interface{}{
+		"first_name":      patient.FirstName,
+		"last_name":       patient.LastName,
+		"dob":             patient.DOB.Format("2006-01-02"),
+		"email":           patient.Email,
+		"gender":          patient.Gender,
+		"phone_number":    patient.PhoneNumber,
+		"address":         patient.Address,
+		"medical_history": patient.MedicalHistory,
+	}
+
+	document := map[string]interface{}{}
+	for field, value := range values {
+		if CanReadField(role, field) {
+			document[field] = value
+		}
+	}
+	return document
+}
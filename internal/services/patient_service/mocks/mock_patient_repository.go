@@ -1,16 +1,20 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockPatientRepository struct {
 	mock.Mock
+	PatchedUpdates map[string]interface{}
 }
 
-func (m *MockPatientRepository) CreatePatient(patient *models.Patient) (*models.Patient, error) {
-	args := m.Called(patient)
+func (m *MockPatientRepository) CreatePatient(patient *models.Patient, changedBy string) (*models.Patient, error) {
+	args := m.Called(patient, changedBy)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.Patient), args.Error(1)
 	}
@@ -25,15 +29,124 @@ func (m *MockPatientRepository) GetPatientById(id uint) (*models.Patient, error)
 	return nil, args.Error(1)
 }
 
-func (m *MockPatientRepository) UpdatePatientById(id uint, updates map[string]interface{}) (*models.Patient, error) {
-	args := m.Called(id, updates)
+// PatchPatientById runs apply against the patient registered for id and
+// records the updates it returned in PatchedUpdates.
+func (m *MockPatientRepository) PatchPatientById(id uint, apply func(current *models.Patient) (map[string]interface{}, error), changedBy string) (*models.Patient, error) {
+	args := m.Called(id, changedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	current := *args.Get(0).(*models.Patient)
+	updates, err := apply(&current)
+	if err != nil {
+		return nil, err
+	}
+	m.PatchedUpdates = updates
+	return &current, args.Error(1)
+}
+
+func (m *MockPatientRepository) ArchivePatientById(id uint, changedBy string) (*models.Patient, error) {
+	args := m.Called(id, changedBy)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Patient), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) RestorePatientById(id uint, changedBy string) (*models.Patient, error) {
+	args := m.Called(id, changedBy)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Patient), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) GetArchivedPatientById(id uint) (*models.Patient, error) {
+	args := m.Called(id)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.Patient), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockPatientRepository) DeletePatientById(id uint) error {
+func (m *MockPatientRepository) PurgePatientById(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockPatientRepository) ListInactivePatientIDs(cutoff time.Time) ([]uint, error) {
+	args := m.Called(cutoff)
+	if args.Get(0) != nil {
+		return args.Get(0).([]uint), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) ListArchivedPatientIDs(archivedBefore time.Time) ([]uint, error) {
+	args := m.Called(archivedBefore)
+	if args.Get(0) != nil {
+		return args.Get(0).([]uint), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) AnonymisePatientById(id uint, changedBy string) error {
+	args := m.Called(id, changedBy)
+	return args.Error(0)
+}
+
+func (m *MockPatientRepository) ListPatients(filter repository.PatientFilter) ([]models.Patient, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Patient), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPatientRepository) FuzzySearchPatients(term string, filter repository.PatientFilter) ([]repository.PatientMatch, int64, error) {
+	args := m.Called(term, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]repository.PatientMatch), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPatientRepository) FindDuplicatePatients(patient *models.Patient) ([]models.Patient, error) {
+	args := m.Called(patient)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Patient), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) MergePatients(sourceID, targetID uint, changedBy string) (*models.Patient, error) {
+	args := m.Called(sourceID, targetID, changedBy)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Patient), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) GetPatientTombstone(id uint) (*models.PatientTombstone, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.PatientTombstone), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) ListPatientVersions(patientID uint) ([]models.PatientVersion, error) {
+	args := m.Called(patientID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.PatientVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPatientRepository) GetPatientVersionAsOf(patientID uint, asOf time.Time) (*models.PatientVersion, error) {
+	args := m.Called(patientID, asOf)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.PatientVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
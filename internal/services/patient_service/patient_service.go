@@ -3,25 +3,78 @@ package patient_service
 import (
 	"errors"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/palashbhasme/healthcare-portal/config"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/patch"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
 	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
 	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
 )
 
+const (
+	defaultPageSize = 20
+	defaultSort     = "created_at"
+)
+
+// DuplicatePatientError is returned by CreatePatient when probable duplicates
+// exist and the request did not set AllowDuplicate.
+type DuplicatePatientError struct {
+	Candidates []*response.PatientResponse
+}
+
+func (e *DuplicatePatientError) Error() string {
+	return "possible duplicate patient"
+}
+
+// PatientMergedError is returned when the requested patient was merged into
+// another record.
+type PatientMergedError struct {
+	TargetID uint
+}
+
+func (e *PatientMergedError) Error() string {
+	return "patient merged"
+}
+
+// PreconditionFailedError is returned by updates whose If-Match versions do
+// not include the patient's current version.
+type PreconditionFailedError struct {
+	CurrentVersion uint
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return "patient version mismatch"
+}
+
+// RetentionPeriodError is returned when purging a patient whose retention
+// period has not yet elapsed.
+type RetentionPeriodError struct {
+	PurgeableAt time.Time
+}
+
+func (e *RetentionPeriodError) Error() string {
+	return "retention period has not elapsed"
+}
+
 type PatientService struct {
-	patientRepo repository.PatientRepository
+	patientRepo     repository.PatientRepository
+	retentionConfig *config.RetentionConfig
 }
 
-func NewPatientService(patientRepo repository.PatientRepository) *PatientService {
+func NewPatientService(patientRepo repository.PatientRepository, retentionConfig *config.RetentionConfig) *PatientService {
 	return &PatientService{
-		patientRepo: patientRepo,
+		patientRepo:     patientRepo,
+		retentionConfig: retentionConfig,
 	}
 }
 
-func (s *PatientService) CreatePatient(patientRequest *request.PatientRequest, role any) (*response.PatientResponse, error) {
+func (s *PatientService) CreatePatient(patientRequest *request.PatientRequest, role any, userID string) (*response.PatientResponse, error) {
 	roleValue, ok := role.(string)
 	if !ok {
 		return nil, errors.New("invalid role type")
@@ -30,19 +83,39 @@ func (s *PatientService) CreatePatient(patientRequest *request.PatientRequest, r
 	if err := services.CheckPermission(roleValue, "create_patient"); err != nil {
 		return nil, errors.New("permission denied")
 	}
+	if err := services.CheckFieldWrite(roleValue, providedPatientFields(patientRequest)); err != nil {
+		return nil, err
+	}
+
 	patient, err := mapper.PatientToModel(patientRequest)
 	if err != nil {
 		return nil, err
 	}
-	newPatient, err := s.patientRepo.CreatePatient(patient)
+
+	if !patientRequest.AllowDuplicate {
+		duplicates, err := s.patientRepo.FindDuplicatePatients(patient)
+		if err != nil {
+			return nil, err
+		}
+		if len(duplicates) > 0 {
+			return nil, &DuplicatePatientError{Candidates: services.PatientsToResponse(duplicates, roleValue)}
+		}
+	}
+
+	newPatient, err := s.patientRepo.CreatePatient(patient, userID)
 	if err != nil {
+		if errors.Is(err, repository.ErrPatientEmailTaken) {
+			return nil, errors.New("email already in use")
+		}
 		return nil, err
 	}
-	patientResponse := mapper.PatientToResponse(newPatient)
+	patientResponse := services.PatientToResponse(newPatient, roleValue)
 	return patientResponse, nil
 }
 
-func (s *PatientService) UpdatePatientById(idStr string, updates map[string]interface{}, role any) (*response.PatientResponse, error) {
+// UpdatePatientById applies the fields set on updateRequest. The update only
+// goes ahead if the patient's current version is in ifMatch.
+func (s *PatientService) UpdatePatientById(idStr string, updateRequest *request.PatientUpdateRequest, ifMatch []uint, role any, userID string) (*response.PatientResponse, error) {
 	roleValue, ok := role.(string)
 	if !ok {
 		return nil, errors.New("invalid role type")
@@ -57,11 +130,80 @@ func (s *PatientService) UpdatePatientById(idStr string, updates map[string]inte
 		return nil, errors.New("invalid patient ID")
 	}
 
-	patient, err := s.patientRepo.UpdatePatientById(uint(id), updates)
+	updates, err := mapper.PatientUpdateToColumns(updateRequest)
+	if err != nil {
+		return nil, errors.New("invalid date of birth")
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	if err := services.CheckFieldWrite(roleValue, fields); err != nil {
+		return nil, err
+	}
+
+	patient, err := s.patientRepo.PatchPatientById(uint(id), func(current *models.Patient) (map[string]interface{}, error) {
+		if err := checkVersion(current, ifMatch); err != nil {
+			return nil, err
+		}
+		return updates, nil
+	}, userID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		if errors.Is(err, repository.ErrPatientEmailTaken) {
+			return nil, errors.New("email already in use")
+		}
 		return nil, err
 	}
-	patientResponse := mapper.PatientToResponse(patient)
+	patientResponse := services.PatientToResponse(patient, roleValue)
+	return patientResponse, nil
+}
+
+// PatchPatientById applies a JSON Patch or JSON Merge Patch to the patient.
+// The patch is evaluated against the locked row, so the If-Match check, test
+// operations and the resulting update happen atomically.
+func (s *PatientService) PatchPatientById(idStr string, p patch.Patch, ifMatch []uint, role any, userID string) (*response.PatientResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "update_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	patient, err := s.patientRepo.PatchPatientById(uint(id), func(current *models.Patient) (map[string]interface{}, error) {
+		if err := checkVersion(current, ifMatch); err != nil {
+			return nil, err
+		}
+		document := services.PatientToDocument(current, roleValue)
+		patched, err := p.Apply(document)
+		if err != nil {
+			return nil, err
+		}
+		return patientChangesToColumns(patch.Diff(document, patched), roleValue)
+	}, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		if errors.Is(err, repository.ErrPatientEmailTaken) {
+			return nil, errors.New("email already in use")
+		}
+		return nil, err
+	}
+	patientResponse := services.PatientToResponse(patient, roleValue)
 	return patientResponse, nil
 }
 
@@ -80,10 +222,406 @@ func (s *PatientService) GetPatientById(idStr string, role any) (*response.Patie
 		return nil, errors.New("invalid patient ID")
 	}
 
-	patient, err := s.patientRepo.GetPatientById(uint(id))
+	patient, err := s.getActivePatient(uint(id))
 	if err != nil {
 		return nil, err
 	}
-	patientResponse := mapper.PatientToResponse(patient)
+	patientResponse := services.PatientToResponse(patient, roleValue)
 	return patientResponse, nil
 }
+
+// getActivePatient loads a patient that is neither archived nor merged away,
+// reporting which of the two happened when it is not found.
+func (s *PatientService) getActivePatient(id uint) (*models.Patient, error) {
+	patient, err := s.patientRepo.GetPatientById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if tombstone, tombErr := s.patientRepo.GetPatientTombstone(id); tombErr == nil {
+				return nil, &PatientMergedError{TargetID: tombstone.TargetID}
+			}
+			if _, archivedErr := s.patientRepo.GetArchivedPatientById(id); archivedErr == nil {
+				return nil, errors.New("patient archived")
+			}
+		}
+		return nil, err
+	}
+	return patient, nil
+}
+
+// ArchivePatient soft deletes the patient. Archived patients are hidden from
+// reads and searches but kept until they are restored or purged.
+func (s *PatientService) ArchivePatient(idStr string, role any, userID string) (*response.PatientResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "archive_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	patient, err := s.patientRepo.ArchivePatientById(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		return nil, err
+	}
+	patientResponse := services.PatientToResponse(patient, roleValue)
+	return patientResponse, nil
+}
+
+func (s *PatientService) RestorePatient(idStr string, role any, userID string) (*response.PatientResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "archive_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	patient, err := s.patientRepo.RestorePatientById(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("archived patient not found")
+		}
+		if errors.Is(err, repository.ErrPatientEmailTaken) {
+			return nil, errors.New("email already in use")
+		}
+		return nil, err
+	}
+	patientResponse := services.PatientToResponse(patient, roleValue)
+	return patientResponse, nil
+}
+
+// PurgePatient permanently deletes an archived patient once the configured
+// retention period has passed since it was archived.
+func (s *PatientService) PurgePatient(idStr string, role any) error {
+	roleValue, ok := role.(string)
+	if !ok {
+		return errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "purge_patient"); err != nil {
+		return errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.New("invalid patient ID")
+	}
+
+	patient, err := s.patientRepo.GetArchivedPatientById(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("archived patient not found")
+		}
+		return err
+	}
+
+	purgeableAt := patient.DeletedAt.Time.Add(s.retentionConfig.PatientRetention)
+	if time.Now().Before(purgeableAt) {
+		return &RetentionPeriodError{PurgeableAt: purgeableAt}
+	}
+
+	return s.patientRepo.PurgePatientById(uint(id))
+}
+
+// GetPatientAsOf reconstructs the patient record as it was at asOf from the
+// version history.
+func (s *PatientService) GetPatientAsOf(idStr string, asOf string, role any) (*response.PatientResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	asOfTime, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		return nil, errors.New("invalid as_of timestamp")
+	}
+
+	if _, err := s.getActivePatient(uint(id)); err != nil {
+		return nil, err
+	}
+
+	version, err := s.patientRepo.GetPatientVersionAsOf(uint(id), asOfTime)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no patient version at that time")
+		}
+		return nil, err
+	}
+	patientResponse := services.PatientToResponse(&version.Snapshot, roleValue)
+	return patientResponse, nil
+}
+
+func (s *PatientService) GetPatientHistory(idStr string, role any) ([]*response.PatientVersionResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	if _, err := s.getActivePatient(uint(id)); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.patientRepo.ListPatientVersions(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return services.PatientVersionsToResponse(versions, roleValue), nil
+}
+
+// MergePatient folds the patient identified by mergeRequest.SourceID into the
+// patient identified by idStr.
+func (s *PatientService) MergePatient(idStr string, mergeRequest *request.PatientMergeRequest, role any, userID string) (*response.PatientResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "merge_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+	if uint(id) == mergeRequest.SourceID {
+		return nil, errors.New("cannot merge a patient into itself")
+	}
+
+	patient, err := s.patientRepo.MergePatients(mergeRequest.SourceID, uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		return nil, err
+	}
+	patientResponse := services.PatientToResponse(patient, roleValue)
+	return patientResponse, nil
+}
+
+func (s *PatientService) ListPatients(query *request.PatientQuery, role any) (*response.PatientListResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+	if query.Archived {
+		if err := services.CheckPermission(roleValue, "archive_patient"); err != nil {
+			return nil, errors.New("permission denied")
+		}
+	}
+
+	filter, err := patientQueryToFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	patients, total, err := s.patientRepo.ListPatients(*filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PatientListResponse{
+		Patients: services.PatientsToResponse(patients, roleValue),
+		Total:    total,
+		Page:     filter.Offset/filter.Limit + 1,
+		PageSize: filter.Limit,
+	}, nil
+}
+
+// SearchPatients performs a typo-tolerant name search, returning matches
+// ranked by score.
+func (s *PatientService) SearchPatients(query *request.PatientQuery, role any) (*response.PatientSearchResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+	if query.Archived {
+		if err := services.CheckPermission(roleValue, "archive_patient"); err != nil {
+			return nil, errors.New("permission denied")
+		}
+	}
+
+	if strings.TrimSpace(query.Name) == "" {
+		return nil, errors.New("name is required for fuzzy search")
+	}
+
+	filter, err := patientQueryToFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, total, err := s.patientRepo.FuzzySearchPatients(strings.TrimSpace(query.Name), *filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PatientSearchResponse{
+		Matches:  patientMatchesToResponse(matches, roleValue),
+		Total:    total,
+		Page:     filter.Offset/filter.Limit + 1,
+		PageSize: filter.Limit,
+	}, nil
+}
+
+func patientQueryToFilter(query *request.PatientQuery) (*repository.PatientFilter, error) {
+	filter := &repository.PatientFilter{
+		NamePrefix:  query.Name,
+		PhoneNumber: query.PhoneNumber,
+		Email:       query.Email,
+		Gender:      query.Gender,
+		SortBy:      query.Sort,
+		SortDesc:    query.Order == "desc",
+		Archived:    query.Archived,
+		Limit:       query.PageSize,
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = defaultSort
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if query.Page > 1 {
+		filter.Offset = (query.Page - 1) * filter.Limit
+	}
+
+	dates := []struct {
+		value string
+		dest  **time.Time
+	}{
+		{query.DOB, &filter.DOB},
+		{query.CreatedFrom, &filter.CreatedFrom},
+		{query.CreatedTo, &filter.CreatedTo},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", d.value)
+		if err != nil {
+			return nil, errors.New("invalid date filter")
+		}
+		*d.dest = &parsed
+	}
+
+	// created_to is inclusive of the whole day
+	if filter.CreatedTo != nil {
+		end := filter.CreatedTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &end
+	}
+
+	return filter, nil
+}
+
+// checkVersion requires the patient's current version to be one of ifMatch.
+// There is no wildcard, so an empty ifMatch never matches.
+func checkVersion(patient *models.Patient, ifMatch []uint) error {
+	for _, version := range ifMatch {
+		if version == patient.Version {
+			return nil
+		}
+	}
+	return &PreconditionFailedError{CurrentVersion: patient.Version}
+}
+
+// patientChangesToColumns validates the members a patch changed and converts
+// them to column updates. Only nullable columns may be removed.
+func patientChangesToColumns(changes map[string]interface{}, role string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	var removed []string
+	for field, value := range changes {
+		if value != nil {
+			values[field] = value
+			continue
+		}
+		if field != "email" {
+			return nil, errors.New("patched patient is invalid")
+		}
+		removed = append(removed, field)
+	}
+
+	var updateRequest request.PatientUpdateRequest
+	if err := request.FromDocument(values, &updateRequest); err != nil {
+		return nil, errors.New("patched patient is invalid")
+	}
+	columns, err := mapper.PatientUpdateToColumns(&updateRequest)
+	if err != nil {
+		return nil, errors.New("patched patient is invalid")
+	}
+	for _, field := range removed {
+		columns[field] = nil
+	}
+
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	if err := services.CheckFieldWrite(role, fields); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// providedPatientFields lists the attributes a create request sets.
+func providedPatientFields(patientRequest *request.PatientRequest) []string {
+	fields := []string{"first_name", "last_name", "dob", "gender", "phone_number", "address"}
+	if patientRequest.Email != "" {
+		fields = append(fields, "email")
+	}
+	if patientRequest.MedicalHistory != "" {
+		fields = append(fields, "medical_history")
+	}
+	return fields
+}
+
+func patientMatchesToResponse(matches []repository.PatientMatch, role string) []*response.PatientMatchResponse {
+	matchResponses := make([]*response.PatientMatchResponse, 0, len(matches))
+	for i := range matches {
+		matchResponses = append(matchResponses, &response.PatientMatchResponse{
+			PatientResponse: services.PatientToResponse(&matches[i].Patient, role),
+			Score:           matches[i].Score,
+		})
+	}
+	return matchResponses
+}
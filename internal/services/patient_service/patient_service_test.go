@@ -3,19 +3,34 @@ package patient_service
 
 import (
 	"errors"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/patch"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
 	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
 	"github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
+var testRetention = &config.RetentionConfig{PatientRetention: 30 * 24 * time.Hour}
+
+func TestMain(m *testing.M) {
+	if err := request.RegisterValidators(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
 func TestCreatePatient_Success(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
 	dob, err := time.Parse("2006-01-02", "1985-07-10")
 	assert.NoError(t, err)
@@ -23,14 +38,13 @@ func TestCreatePatient_Success(t *testing.T) {
 	email := "patient@example.com"
 
 	patientReq := &request.PatientRequest{
-		FirstName:      "Patient1",
-		LastName:       "Louis",
-		DOB:            "1985-07-10",
-		Email:          email,
-		Gender:         "male",
-		PhoneNumber:    "1234567890",
-		Address:        "Church Street",
-		MedicalHistory: "Diabetes",
+		FirstName:   "Patient1",
+		LastName:    "Louis",
+		DOB:         "1985-07-10",
+		Email:       email,
+		Gender:      "male",
+		PhoneNumber: "1234567890",
+		Address:     "Church Street",
 	}
 
 	mockPatientModel := &models.Patient{
@@ -44,9 +58,10 @@ func TestCreatePatient_Success(t *testing.T) {
 		MedicalHistory: "Diabetes",
 	}
 
-	mockRepo.On("CreatePatient", mock.AnythingOfType("*models.Patient")).Return(mockPatientModel, nil)
+	mockRepo.On("FindDuplicatePatients", mock.AnythingOfType("*models.Patient")).Return([]models.Patient{}, nil)
+	mockRepo.On("CreatePatient", mock.AnythingOfType("*models.Patient"), "2").Return(mockPatientModel, nil)
 
-	result, err := service.CreatePatient(patientReq, "receptionist")
+	result, err := service.CreatePatient(patientReq, "receptionist", "2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -57,7 +72,7 @@ func TestCreatePatient_Success(t *testing.T) {
 
 func TestCreatePatient_PermissionDenied(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 	email := "patient@example.com"
 
 	patientReq := &request.PatientRequest{
@@ -71,7 +86,7 @@ func TestCreatePatient_PermissionDenied(t *testing.T) {
 		MedicalHistory: "Diabetes",
 	}
 
-	result, err := service.CreatePatient(patientReq, "doctor") // Doctor cannot create patients
+	result, err := service.CreatePatient(patientReq, "doctor", "1") // Doctor cannot create patients
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -80,7 +95,7 @@ func TestCreatePatient_PermissionDenied(t *testing.T) {
 
 func TestGetPatientById_Success(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
 	mockPatientModel := &models.Patient{
 		FirstName: "Jane",
@@ -100,7 +115,7 @@ func TestGetPatientById_Success(t *testing.T) {
 
 func TestGetPatientById_NotFound(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
 	mockRepo.On("GetPatientById", uint(99)).Return(nil, errors.New("not found"))
 
@@ -113,56 +128,661 @@ func TestGetPatientById_NotFound(t *testing.T) {
 
 func TestUpdatePatientById_Success(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
-	updates := map[string]interface{}{"FirstName": "John"}
+	updates := &request.PatientUpdateRequest{FirstName: strPtr("John")}
 	mockPatientModel := &models.Patient{
+		Version:   1,
 		FirstName: "John",
 		LastName:  "Doe",
 	}
 
-	mockRepo.On("UpdatePatientById", uint(1), updates).Return(mockPatientModel, nil)
+	columns := map[string]interface{}{"first_name": "John"}
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(mockPatientModel, nil)
 
-	result, err := service.UpdatePatientById("1", updates, "receptionist")
+	result, err := service.UpdatePatientById("1", updates, []uint{1}, "receptionist", "2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "John", result.FirstName)
+	assert.Equal(t, columns, mockRepo.PatchedUpdates)
 	mockRepo.AssertExpectations(t)
 }
 
 // To simulate a successful update using doctor role
 func TestDoctorUpdatePatientById_Success(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
-	updates := map[string]interface{}{"FirstName": "John"}
+	updates := &request.PatientUpdateRequest{MedicalHistory: strPtr("Hypertension")}
 	mockPatientModel := &models.Patient{
-		FirstName: "John",
-		LastName:  "Doe",
+		Version:        1,
+		FirstName:      "John",
+		LastName:       "Doe",
+		MedicalHistory: "Hypertension",
 	}
 
-	mockRepo.On("UpdatePatientById", uint(1), updates).Return(mockPatientModel, nil)
+	columns := map[string]interface{}{"medical_history": "Hypertension"}
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(mockPatientModel, nil)
 
-	result, err := service.UpdatePatientById("1", updates, "doctor")
+	result, err := service.UpdatePatientById("1", updates, []uint{1}, "doctor", "2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, "John", result.FirstName)
+	assert.Equal(t, "Hypertension", *result.MedicalHistory)
+	assert.Equal(t, columns, mockRepo.PatchedUpdates)
 	mockRepo.AssertExpectations(t)
 }
 
+// Doctors may not change demographics
+func TestDoctorUpdatePatientById_DemographicsDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	updates := &request.PatientUpdateRequest{
+		FirstName:      strPtr("John"),
+		MedicalHistory: strPtr("Hypertension"),
+		DOB:            strPtr("1990-01-01"),
+	}
+
+	result, err := service.UpdatePatientById("1", updates, []uint{1}, "doctor", "2")
+
+	assert.Nil(t, result)
+	var fieldErr *services.FieldAccessError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, []string{"dob", "first_name"}, fieldErr.Fields)
+	mockRepo.AssertNotCalled(t, "PatchPatientById", mock.Anything, mock.Anything)
+}
+
+func TestReceptionistUpdatePatientById_ClinicalDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	updates := &request.PatientUpdateRequest{MedicalHistory: strPtr("Asthma")}
+
+	result, err := service.UpdatePatientById("1", updates, []uint{1}, "receptionist", "2")
+
+	assert.Nil(t, result)
+	var fieldErr *services.FieldAccessError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, []string{"medical_history"}, fieldErr.Fields)
+}
+
+func TestCreatePatient_ReceptionistCannotWriteClinical(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	patientReq := &request.PatientRequest{
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		DOB:            "1990-01-01",
+		Gender:         "female",
+		PhoneNumber:    "5550100",
+		Address:        "Main Street",
+		MedicalHistory: "Asthma",
+	}
+
+	result, err := service.CreatePatient(patientReq, "receptionist", "2")
+
+	assert.Nil(t, result)
+	var fieldErr *services.FieldAccessError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, []string{"medical_history"}, fieldErr.Fields)
+}
+
+func TestGetPatientById_ReceptionistCannotReadClinical(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, FirstName: "Jane", MedicalHistory: "Asthma"}, nil)
+
+	receptionistView, err := service.GetPatientById("1", "receptionist")
+	assert.NoError(t, err)
+	assert.Nil(t, receptionistView.MedicalHistory)
+	assert.Equal(t, "Jane", receptionistView.FirstName)
+
+	doctorView, err := service.GetPatientById("1", "doctor")
+	assert.NoError(t, err)
+	assert.Equal(t, "Asthma", *doctorView.MedicalHistory)
+}
+
 func TestUpdatePatientById_NotFound(t *testing.T) {
 	mockRepo := new(mocks.MockPatientRepository)
-	service := NewPatientService(mockRepo)
+	service := NewPatientService(mockRepo, testRetention)
 
-	updates := map[string]interface{}{"FirstName": "John"}
+	updates := &request.PatientUpdateRequest{FirstName: strPtr("John")}
 
-	mockRepo.On("UpdatePatientById", uint(99), updates).Return(nil, errors.New("not found"))
+	mockRepo.On("PatchPatientById", uint(99), "2").Return(nil, gorm.ErrRecordNotFound)
 
-	result, err := service.UpdatePatientById("99", updates, "receptionist")
+	result, err := service.UpdatePatientById("99", updates, []uint{1}, "receptionist", "2")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, "not found", err.Error())
+	assert.Equal(t, "patient not found", err.Error())
+}
+
+func TestUpdatePatientById_ParsesDOB(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	updates := &request.PatientUpdateRequest{DOB: strPtr("1990-01-01")}
+	columns := map[string]interface{}{"dob": time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(&models.Patient{ID: 1, Version: 1}, nil)
+
+	_, err := service.UpdatePatientById("1", updates, []uint{1}, "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, columns, mockRepo.PatchedUpdates)
+}
+
+func TestUpdatePatientById_IfMatch(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(&models.Patient{ID: 1, Version: 3}, nil)
+	updates := &request.PatientUpdateRequest{FirstName: strPtr("John")}
+
+	result, err := service.UpdatePatientById("1", updates, []uint{2}, "receptionist", "2")
+
+	assert.Nil(t, result)
+	var preconditionErr *PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+	assert.Equal(t, uint(3), preconditionErr.CurrentVersion)
+	assert.Nil(t, mockRepo.PatchedUpdates)
+
+	// there is no wildcard version
+	result, err = service.UpdatePatientById("1", updates, nil, "receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.ErrorAs(t, err, &preconditionErr)
+	assert.Nil(t, mockRepo.PatchedUpdates)
+
+	result, err = service.UpdatePatientById("1", updates, []uint{2, 3}, "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), result.Version)
+	assert.Equal(t, map[string]interface{}{"first_name": "John"}, mockRepo.PatchedUpdates)
+}
+
+func TestUpdatePatientById_NoFields(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	result, err := service.UpdatePatientById("1", &request.PatientUpdateRequest{}, []uint{1}, "receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "no fields to update")
+	mockRepo.AssertNotCalled(t, "PatchPatientById", mock.Anything, mock.Anything)
+}
+
+func TestPatchPatientById_JSONPatch(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	email := "jane@example.com"
+	current := &models.Patient{ID: 1, Version: 1, FirstName: "Jane", LastName: "Doe", Email: &email, PhoneNumber: "5550100"}
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(current, nil)
+
+	p, err := patch.Parse(patch.JSONPatchMediaType, []byte(`[
+		{"op":"test","path":"/phone_number","value":"5550100"},
+		{"op":"replace","path":"/phone_number","value":"5550199"},
+		{"op":"remove","path":"/email"}
+	]`))
+	assert.NoError(t, err)
+
+	_, err = service.PatchPatientById("1", p, []uint{1}, "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"phone_number": "5550199", "email": nil}, mockRepo.PatchedUpdates)
+}
+
+func TestPatchPatientById_TestFailed(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(&models.Patient{ID: 1, Version: 1, PhoneNumber: "5550100"}, nil)
+
+	p, err := patch.Parse(patch.JSONPatchMediaType, []byte(`[
+		{"op":"test","path":"/phone_number","value":"5550111"},
+		{"op":"replace","path":"/phone_number","value":"5550199"}
+	]`))
+	assert.NoError(t, err)
+
+	result, err := service.PatchPatientById("1", p, []uint{1}, "receptionist", "2")
+
+	assert.Nil(t, result)
+	var testErr *patch.TestFailedError
+	assert.ErrorAs(t, err, &testErr)
+	assert.Nil(t, mockRepo.PatchedUpdates)
+}
+
+func TestPatchPatientById_MergePatchRejectsUnknownAndRequired(t *testing.T) {
+	cases := map[string]string{
+		"unknown member":  `{"id":5}`,
+		"required member": `{"last_name":null}`,
+		"invalid phone":   `{"phone_number":"not a phone"}`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(mocks.MockPatientRepository)
+			service := NewPatientService(mockRepo, testRetention)
+			mockRepo.On("PatchPatientById", uint(1), "2").Return(&models.Patient{ID: 1, Version: 1, LastName: "Doe"}, nil)
+
+			p, err := patch.Parse(patch.MergePatchMediaType, []byte(body))
+			assert.NoError(t, err)
+
+			_, err = service.PatchPatientById("1", p, []uint{1}, "receptionist", "2")
+
+			assert.EqualError(t, err, "patched patient is invalid")
+		})
+	}
+}
+
+func TestPatchPatientById_HiddenFieldDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("PatchPatientById", uint(1), "2").Return(&models.Patient{ID: 1, Version: 1, MedicalHistory: "Asthma"}, nil)
+
+	// receptionists cannot see medical_history, so a test on it must fail
+	p, err := patch.Parse(patch.JSONPatchMediaType, []byte(`[{"op":"test","path":"/medical_history","value":"Asthma"}]`))
+	assert.NoError(t, err)
+	_, err = service.PatchPatientById("1", p, []uint{1}, "receptionist", "2")
+	var testErr *patch.TestFailedError
+	assert.ErrorAs(t, err, &testErr)
+
+	p, err = patch.Parse(patch.MergePatchMediaType, []byte(`{"medical_history":"None"}`))
+	assert.NoError(t, err)
+	_, err = service.PatchPatientById("1", p, []uint{1}, "receptionist", "2")
+	var fieldErr *services.FieldAccessError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, []string{"medical_history"}, fieldErr.Fields)
+}
+
+func TestArchivePatient_Success(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	archivedAt := time.Now()
+	mockRepo.On("ArchivePatientById", uint(1), "2").
+		Return(&models.Patient{ID: 1, DeletedAt: gorm.DeletedAt{Time: archivedAt, Valid: true}}, nil)
+
+	result, err := service.ArchivePatient("1", "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, archivedAt, *result.ArchivedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestArchivePatient_DoctorDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	result, err := service.ArchivePatient("1", "doctor", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockRepo.AssertNotCalled(t, "ArchivePatientById", mock.Anything, mock.Anything)
+}
+
+func TestRestorePatient_NotArchived(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("RestorePatientById", uint(1), "2").Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := service.RestorePatient("1", "receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "archived patient not found")
+}
+
+func TestGetPatientById_Archived(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetPatientTombstone", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetArchivedPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	result, err := service.GetPatientById("1", "receptionist")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "patient archived")
+}
+
+func TestPurgePatient_RespectsRetention(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	recent := time.Now().Add(-24 * time.Hour)
+	mockRepo.On("GetArchivedPatientById", uint(1)).
+		Return(&models.Patient{ID: 1, DeletedAt: gorm.DeletedAt{Time: recent, Valid: true}}, nil)
+
+	err := service.PurgePatient("1", "admin")
+
+	var retentionErr *RetentionPeriodError
+	assert.ErrorAs(t, err, &retentionErr)
+	assert.Equal(t, recent.Add(testRetention.PatientRetention), retentionErr.PurgeableAt)
+	mockRepo.AssertNotCalled(t, "PurgePatientById", mock.Anything)
+}
+
+func TestPurgePatient_AfterRetention(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	old := time.Now().Add(-testRetention.PatientRetention - time.Hour)
+	mockRepo.On("GetArchivedPatientById", uint(1)).
+		Return(&models.Patient{ID: 1, DeletedAt: gorm.DeletedAt{Time: old, Valid: true}}, nil)
+	mockRepo.On("PurgePatientById", uint(1)).Return(nil)
+
+	err := service.PurgePatient("1", "admin")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPurgePatient_ReceptionistDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	err := service.PurgePatient("1", "receptionist")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListPatients_DefaultsAndFilters(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	dob, err := time.Parse("2006-01-02", "1985-07-10")
+	assert.NoError(t, err)
+
+	expectedFilter := repository.PatientFilter{
+		NamePrefix: "Lou",
+		DOB:        &dob,
+		SortBy:     "created_at",
+		Limit:      20,
+		Offset:     40,
+	}
+	mockRepo.On("ListPatients", expectedFilter).Return([]models.Patient{{FirstName: "Louis"}}, int64(41), nil)
+
+	result, err := service.ListPatients(&request.PatientQuery{Name: "Lou", DOB: "1985-07-10", Page: 3}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(41), result.Total)
+	assert.Equal(t, 3, result.Page)
+	assert.Equal(t, 20, result.PageSize)
+	assert.Len(t, result.Patients, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListPatients_PermissionDenied(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	result, err := service.ListPatients(&request.PatientQuery{}, "janitor")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "permission denied", err.Error())
+}
+
+func TestSearchPatients_ReturnsScores(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	matches := []repository.PatientMatch{
+		{Patient: models.Patient{ID: 1, FirstName: "Louis"}, Score: 0.8},
+		{Patient: models.Patient{ID: 2, FirstName: "Lewis"}, Score: 0.5},
+	}
+	mockRepo.On("FuzzySearchPatients", "Luis", mock.AnythingOfType("repository.PatientFilter")).Return(matches, int64(2), nil)
+
+	result, err := service.SearchPatients(&request.PatientQuery{Mode: "fuzzy", Name: " Luis "}, "receptionist")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Matches, 2)
+	assert.Equal(t, "Louis", result.Matches[0].FirstName)
+	assert.Equal(t, 0.8, result.Matches[0].Score)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchPatients_RequiresName(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	result, err := service.SearchPatients(&request.PatientQuery{Mode: "fuzzy"}, "receptionist")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "name is required for fuzzy search", err.Error())
+}
+
+func TestCreatePatient_Duplicate(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	patientReq := &request.PatientRequest{
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		DOB:         "1990-01-01",
+		Gender:      "female",
+		PhoneNumber: "5550100",
+		Address:     "Main Street",
+	}
+
+	existing := []models.Patient{{ID: 7, FirstName: "Jane", LastName: "Doe"}}
+	mockRepo.On("FindDuplicatePatients", mock.AnythingOfType("*models.Patient")).Return(existing, nil)
+
+	result, err := service.CreatePatient(patientReq, "receptionist", "2")
+
+	assert.Nil(t, result)
+	var duplicateErr *DuplicatePatientError
+	assert.ErrorAs(t, err, &duplicateErr)
+	assert.Len(t, duplicateErr.Candidates, 1)
+	assert.Equal(t, uint(7), duplicateErr.Candidates[0].ID)
+	mockRepo.AssertNotCalled(t, "CreatePatient", mock.Anything, mock.Anything)
+}
+
+func TestCreatePatient_AllowDuplicate(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	patientReq := &request.PatientRequest{
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		DOB:            "1990-01-01",
+		Gender:         "female",
+		PhoneNumber:    "5550100",
+		Address:        "Main Street",
+		AllowDuplicate: true,
+	}
+
+	mockRepo.On("CreatePatient", mock.AnythingOfType("*models.Patient"), "2").Return(&models.Patient{ID: 8, FirstName: "Jane"}, nil)
+
+	result, err := service.CreatePatient(patientReq, "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), result.ID)
+	mockRepo.AssertNotCalled(t, "FindDuplicatePatients", mock.Anything)
+}
+
+func TestCreatePatient_EmailInUse(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	patientReq := &request.PatientRequest{
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		DOB:            "1990-01-01",
+		Email:          "jane@example.com",
+		Gender:         "female",
+		PhoneNumber:    "5550100",
+		Address:        "Main Street",
+		AllowDuplicate: true,
+	}
+
+	mockRepo.On("CreatePatient", mock.AnythingOfType("*models.Patient"), "2").Return(nil, repository.ErrPatientEmailTaken)
+
+	result, err := service.CreatePatient(patientReq, "receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "email already in use")
+}
+
+func TestRestorePatient_EmailInUse(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	// the email was registered again while this record was archived
+	mockRepo.On("RestorePatientById", uint(1), "2").Return(nil, repository.ErrPatientEmailTaken)
+
+	result, err := service.RestorePatient("1", "receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "email already in use")
+}
+
+func TestGetPatientById_Merged(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetPatientTombstone", uint(3)).Return(&models.PatientTombstone{MergedID: 3, TargetID: 9}, nil)
+
+	result, err := service.GetPatientById("3", "doctor")
+
+	assert.Nil(t, result)
+	var mergedErr *PatientMergedError
+	assert.ErrorAs(t, err, &mergedErr)
+	assert.Equal(t, uint(9), mergedErr.TargetID)
+}
+
+func TestMergePatient_IntoItself(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	result, err := service.MergePatient("4", &request.PatientMergeRequest{SourceID: 4}, "receptionist", "2")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "cannot merge a patient into itself", err.Error())
+}
+
+func TestMergePatient_Success(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("MergePatients", uint(3), uint(9), "2").Return(&models.Patient{ID: 9, FirstName: "Jane"}, nil)
+
+	result, err := service.MergePatient("9", &request.PatientMergeRequest{SourceID: 3}, "receptionist", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), result.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetPatientAsOf_ReturnsSnapshot(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	asOf := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	version := &models.PatientVersion{
+		PatientID: 1,
+		Version:   2,
+		Snapshot:  models.Patient{ID: 1, FirstName: "Jon", LastName: "Doe"},
+	}
+	mockRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	mockRepo.On("GetPatientVersionAsOf", uint(1), asOf).Return(version, nil)
+
+	result, err := service.GetPatientAsOf("1", "2025-03-01T12:00:00Z", "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jon", result.FirstName)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetPatientAsOf_BeforeHistory(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	mockRepo.On("GetPatientVersionAsOf", uint(1), mock.AnythingOfType("time.Time")).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := service.GetPatientAsOf("1", "1999-01-01T00:00:00Z", "doctor")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "no patient version at that time", err.Error())
+}
+
+func TestGetPatientHistory_MapsChanges(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	versions := []models.PatientVersion{
+		{Version: 1, ChangeType: models.PatientChangeCreate, ChangedBy: "2"},
+		{Version: 2, ChangeType: models.PatientChangeUpdate, ChangedBy: "3",
+			Changes: []models.FieldChange{{Field: "first_name", Old: "Jon", New: "John"}}},
+	}
+	mockRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	mockRepo.On("ListPatientVersions", uint(1)).Return(versions, nil)
+
+	result, err := service.GetPatientHistory("1", "receptionist")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "update", result[1].ChangeType)
+	assert.Equal(t, "first_name", result[1].Changes[0].Field)
+	assert.Equal(t, "John", result[1].Changes[0].New)
+}
+
+func TestGetPatientAsOf_Archived(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetPatientTombstone", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetArchivedPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	result, err := service.GetPatientAsOf("1", "2025-03-01T12:00:00Z", "doctor")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "patient archived")
+	mockRepo.AssertNotCalled(t, "GetPatientVersionAsOf", mock.Anything, mock.Anything)
+}
+
+func TestGetPatientHistory_Archived(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetPatientTombstone", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetArchivedPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	result, err := service.GetPatientHistory("1", "doctor")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "patient archived")
+	mockRepo.AssertNotCalled(t, "ListPatientVersions", mock.Anything)
+}
+
+func TestGetPatientHistory_Merged(t *testing.T) {
+	mockRepo := new(mocks.MockPatientRepository)
+	service := NewPatientService(mockRepo, testRetention)
+
+	mockRepo.On("GetPatientById", uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetPatientTombstone", uint(2)).Return(&models.PatientTombstone{MergedID: 2, TargetID: 1}, nil)
+
+	result, err := service.GetPatientHistory("2", "doctor")
+
+	var mergedErr *PatientMergedError
+	assert.Nil(t, result)
+	assert.ErrorAs(t, err, &mergedErr)
+	assert.Equal(t, uint(1), mergedErr.TargetID)
+	mockRepo.AssertNotCalled(t, "ListPatientVersions", mock.Anything)
+}
+
+func strPtr(s string) *string {
+	return &s
 }
@@ -3,11 +3,67 @@ package services
 import (
 	"errors"
 	"fmt"
+	"sort"
 )
 
 var RolePermissionMap = map[string][]string{
-	"doctor":       {"update_patient", "view_patient"},
-	"receptionist": {"create_patient", "delete_patient", "update_patient", "view_patient"},
+	"doctor": {"update_patient", "view_patient", "view_condition", "manage_condition", "search_codes",
+		"view_allergy", "update_allergy",
+		"view_vitals", "record_vitals", "manage_vital_rules",
+		"view_worklist", "update_worklist",
+		"view_encounter", "manage_encounter", "view_note", "write_note", "sign_note",
+		"view_lab", "order_lab", "manage_lab",
+		"view_immunization", "record_immunization", "view_immunization_forecast",
+		"view_prescription", "create_prescription", "sign_prescription", "discontinue_prescription",
+		"view_appointment",
+		"view_availability", "manage_availability"},
+	"receptionist": {"create_patient", "archive_patient", "update_patient", "view_patient", "merge_patient",
+		"create_appointment", "update_appointment", "cancel_appointment", "view_appointment",
+		"view_encounter", "manage_encounter", "view_lab_status", "view_immunization_forecast",
+		"view_availability", "manage_availability"},
+	// dispensing is kept from doctors so a second person checks every
+	// prescription before it reaches the patient
+	"pharmacist": {"view_patient", "view_allergy", "view_prescription", "dispense_prescription"},
+	"admin":      {"view_audit_log", "purge_patient", "manage_retention"},
+}
+
+// Patient attributes grouped by sensitivity. Names match the JSON keys of the
+// patient request and response DTOs.
+var (
+	PatientDemographicFields = []string{"first_name", "last_name", "dob", "gender"}
+	PatientContactFields     = []string{"email", "phone_number", "address"}
+	PatientClinicalFields    = []string{"medical_history"}
+)
+
+type FieldAccess struct {
+	Read  []string
+	Write []string
+}
+
+// RoleFieldAccessMap controls which patient attributes each role may see and
+// change. Roles without an entry cannot read or write any attribute.
+var RoleFieldAccessMap = map[string]FieldAccess{
+	"doctor": {
+		Read:  fieldGroups(PatientDemographicFields, PatientContactFields, PatientClinicalFields),
+		Write: fieldGroups(PatientClinicalFields),
+	},
+	"receptionist": {
+		Read:  fieldGroups(PatientDemographicFields, PatientContactFields),
+		Write: fieldGroups(PatientDemographicFields, PatientContactFields),
+	},
+	"pharmacist": {
+		Read: fieldGroups(PatientDemographicFields),
+	},
+}
+
+// FieldAccessError lists the attributes a role attempted to touch without
+// access.
+type FieldAccessError struct {
+	Fields []string
+}
+
+func (e *FieldAccessError) Error() string {
+	return "field access denied"
 }
 
 func CheckPermission(role, permission string) error {
@@ -23,3 +79,44 @@ func CheckPermission(role, permission string) error {
 	}
 	return fmt.Errorf("permission denied: %s", permission)
 }
+
+func CanReadField(role, field string) bool {
+	return contains(RoleFieldAccessMap[role].Read, field)
+}
+
+func CanWriteField(role, field string) bool {
+	return contains(RoleFieldAccessMap[role].Write, field)
+}
+
+// CheckFieldWrite returns a *FieldAccessError naming every field in fields the
+// role may not write, or nil if all are allowed.
+func CheckFieldWrite(role string, fields []string) error {
+	var denied []string
+	for _, field := range fields {
+		if !CanWriteField(role, field) {
+			denied = append(denied, field)
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+	sort.Strings(denied)
+	return &FieldAccessError{Fields: denied}
+}
+
+func fieldGroups(groups ...[]string) []string {
+	var fields []string
+	for _, group := range groups {
+		fields = append(fields, group...)
+	}
+	return fields
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
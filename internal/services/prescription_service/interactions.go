@@ -0,0 +1,200 @@
+package prescription_service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+)
+
+// bundledInteractions is the interaction table used when no file is
+// configured.
+//
+//go:embed interactions.json
+var bundledInteractions []byte
+
+// InteractionTable holds the drug classes, allergy cross-reactions and
+// drug-drug interactions prescriptions are checked against. Rule terms may
+// name a drug or a class; matching is case-insensitive.
+type InteractionTable struct {
+	Classes        map[string][]string `json:"classes"`
+	CrossReactions []CrossReaction     `json:"cross_reactions"`
+	DrugDrug       []DrugInteraction   `json:"drug_drug"`
+
+	// memberOf maps a drug to the classes it belongs to
+	memberOf map[string][]string
+}
+
+// CrossReaction flags a drug that may trigger an allergy to a different
+// substance.
+type CrossReaction struct {
+	Allergen    string                     `json:"allergen"`
+	Drug        string                     `json:"drug"`
+	Severity    models.InteractionSeverity `json:"severity"`
+	Description string                     `json:"description"`
+}
+
+type DrugInteraction struct {
+	Drugs       [2]string                  `json:"drugs"`
+	Severity    models.InteractionSeverity `json:"severity"`
+	Description string                     `json:"description"`
+}
+
+// LoadInteractionTable reads the table from path, or the bundled table when
+// path is empty.
+func LoadInteractionTable(path string) (*InteractionTable, error) {
+	if path == "" {
+		return ParseInteractionTable(bytes.NewReader(bundledInteractions))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	table, err := ParseInteractionTable(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return table, nil
+}
+
+func ParseInteractionTable(r io.Reader) (*InteractionTable, error) {
+	var table InteractionTable
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range table.CrossReactions {
+		if err := checkSeverity(rule.Severity); err != nil {
+			return nil, fmt.Errorf("cross reaction %s/%s: %w", rule.Allergen, rule.Drug, err)
+		}
+	}
+	for _, rule := range table.DrugDrug {
+		if err := checkSeverity(rule.Severity); err != nil {
+			return nil, fmt.Errorf("interaction %s/%s: %w", rule.Drugs[0], rule.Drugs[1], err)
+		}
+	}
+
+	table.memberOf = map[string][]string{}
+	for class, drugs := range table.Classes {
+		for _, drug := range drugs {
+			drug = normalizeDrug(drug)
+			table.memberOf[drug] = append(table.memberOf[drug], normalizeDrug(class))
+		}
+	}
+	return &table, nil
+}
+
+// Check returns the conflicts between drug and the patient's allergies and
+// active medications. A drug that is, belongs to a class named by, or shares
+// a class with a relevant allergy substance is always a high-severity
+// conflict.
+func (t *InteractionTable) Check(drug string, allergies []models.Allergy, medications []models.Prescription) []models.InteractionWarning {
+	warnings := []models.InteractionWarning{}
+
+	for _, allergy := range allergies {
+		if !allergy.IsRelevant() {
+			continue
+		}
+		if t.matches(drug, allergy.Substance) {
+			warnings = append(warnings, models.InteractionWarning{
+				Type:        models.InteractionDrugAllergy,
+				Severity:    models.InteractionHigh,
+				Conflict:    allergy.Substance,
+				Description: fmt.Sprintf("Patient has a recorded %s allergy to %s", allergy.Severity, allergy.Substance),
+			})
+			continue
+		}
+		if class, ok := t.sharedClass(drug, allergy.Substance); ok {
+			warnings = append(warnings, models.InteractionWarning{
+				Type:        models.InteractionDrugAllergy,
+				Severity:    models.InteractionHigh,
+				Conflict:    allergy.Substance,
+				Description: fmt.Sprintf("Patient is allergic to %s, which is in the same class (%s)", allergy.Substance, class),
+			})
+			continue
+		}
+		for _, rule := range t.CrossReactions {
+			if t.matches(allergy.Substance, rule.Allergen) && t.matches(drug, rule.Drug) {
+				warnings = append(warnings, models.InteractionWarning{
+					Type:        models.InteractionDrugAllergy,
+					Severity:    rule.Severity,
+					Conflict:    allergy.Substance,
+					Description: rule.Description,
+				})
+			}
+		}
+	}
+
+	for _, medication := range medications {
+		for _, rule := range t.DrugDrug {
+			if (t.matches(drug, rule.Drugs[0]) && t.matches(medication.Drug, rule.Drugs[1])) ||
+				(t.matches(drug, rule.Drugs[1]) && t.matches(medication.Drug, rule.Drugs[0])) {
+				warnings = append(warnings, models.InteractionWarning{
+					Type:        models.InteractionDrugDrug,
+					Severity:    rule.Severity,
+					Conflict:    medication.Drug,
+					Description: rule.Description,
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// matches reports whether drug is term or belongs to the class term. Combination
+// products such as "amoxicillin/clavulanate" match on each component.
+func (t *InteractionTable) matches(drug, term string) bool {
+	term = normalizeDrug(term)
+	for _, component := range strings.FieldsFunc(drug, func(r rune) bool { return r == '/' || r == '+' }) {
+		component = normalizeDrug(component)
+		if component == term {
+			return true
+		}
+		for _, class := range t.memberOf[component] {
+			if class == term {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sharedClass returns a class that both drug and substance belong to.
+func (t *InteractionTable) sharedClass(drug, substance string) (string, bool) {
+	for _, class := range t.memberOf[normalizeDrug(substance)] {
+		if t.matches(drug, class) {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// HasHighSeverity reports whether any warning is high severity.
+func HasHighSeverity(warnings []models.InteractionWarning) bool {
+	for _, warning := range warnings {
+		if warning.Severity == models.InteractionHigh {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDrug(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func checkSeverity(severity models.InteractionSeverity) error {
+	switch severity {
+	case models.InteractionLow, models.InteractionModerate, models.InteractionHigh:
+		return nil
+	}
+	return fmt.Errorf("invalid severity %q", severity)
+}
@@ -0,0 +1,68 @@
+package prescription_service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func bundledTable(t *testing.T) *InteractionTable {
+	table, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+	return table
+}
+
+func TestCheck_AllergyToClass(t *testing.T) {
+	allergies := []models.Allergy{{Substance: "penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyConfirmed}}
+
+	warnings := bundledTable(t).Check("Amoxicillin/Clavulanate", allergies, nil)
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.InteractionDrugAllergy, warnings[0].Type)
+	assert.Equal(t, models.InteractionHigh, warnings[0].Severity)
+}
+
+func TestCheck_AllergyCrossReaction(t *testing.T) {
+	allergies := []models.Allergy{{Substance: "Penicillin", Severity: models.AllergyMild, VerificationStatus: models.AllergyConfirmed}}
+
+	warnings := bundledTable(t).Check("Cephalexin", allergies, nil)
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.InteractionModerate, warnings[0].Severity)
+}
+
+func TestCheck_IgnoresRefutedAllergies(t *testing.T) {
+	allergies := []models.Allergy{{Substance: "Penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyRefuted}}
+
+	warnings := bundledTable(t).Check("Amoxicillin", allergies, nil)
+
+	assert.Empty(t, warnings)
+}
+
+func TestCheck_DrugDrugInEitherOrder(t *testing.T) {
+	table := bundledTable(t)
+
+	warnings := table.Check("Sildenafil", nil, []models.Prescription{{Drug: "Isosorbide  Mononitrate"}})
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.InteractionDrugDrug, warnings[0].Type)
+	assert.Equal(t, "Isosorbide  Mononitrate", warnings[0].Conflict)
+
+	warnings = table.Check("Nitroglycerin", nil, []models.Prescription{{Drug: "tadalafil"}})
+	assert.Len(t, warnings, 1)
+	assert.True(t, HasHighSeverity(warnings))
+}
+
+func TestCheck_NoConflicts(t *testing.T) {
+	warnings := bundledTable(t).Check("Paracetamol", nil, []models.Prescription{{Drug: "Amlodipine"}})
+
+	assert.Empty(t, warnings)
+	assert.False(t, HasHighSeverity(warnings))
+}
+
+func TestParseInteractionTable_RejectsUnknownSeverity(t *testing.T) {
+	_, err := ParseInteractionTable(strings.NewReader(`{"drug_drug":[{"drugs":["a","b"],"severity":"severe","description":"x"}]}`))
+
+	assert.Error(t, err)
+}
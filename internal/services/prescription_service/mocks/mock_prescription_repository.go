@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPrescriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPrescriptionRepository) CreatePrescription(prescription *models.Prescription) (*models.Prescription, error) {
+	args := m.Called(prescription)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Prescription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPrescriptionRepository) GetPrescriptionById(patientID, id uint) (*models.Prescription, error) {
+	args := m.Called(patientID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Prescription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPrescriptionRepository) ListPrescriptions(patientID uint, statuses []models.PrescriptionStatus) ([]models.Prescription, error) {
+	args := m.Called(patientID, statuses)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Prescription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPrescriptionRepository) UpdatePrescriptionById(patientID, id uint, fromStatuses []models.PrescriptionStatus, updates map[string]interface{}) (*models.Prescription, error) {
+	args := m.Called(patientID, id, fromStatuses, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Prescription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPrescriptionRepository) DeleteDraftPrescriptionById(patientID, id uint) error {
+	args := m.Called(patientID, id)
+	return args.Error(0)
+}
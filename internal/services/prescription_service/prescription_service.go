@@ -0,0 +1,318 @@
+package prescription_service
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+// InteractionConflictError is returned when a prescription has high-severity
+// interactions and no override reason was given.
+type InteractionConflictError struct {
+	Warnings []*response.InteractionWarningResponse
+}
+
+func (e *InteractionConflictError) Error() string {
+	return "high-severity interaction requires an override reason"
+}
+
+type PrescriptionService struct {
+	prescriptionRepo repository.PrescriptionRepository
+	patientRepo      repository.PatientRepository
+	allergyRepo      repository.AllergyRepository
+	encounterRepo    repository.EncounterRepository
+	interactions     *InteractionTable
+}
+
+func NewPrescriptionService(prescriptionRepo repository.PrescriptionRepository,
+	patientRepo repository.PatientRepository,
+	allergyRepo repository.AllergyRepository,
+	encounterRepo repository.EncounterRepository,
+	interactions *InteractionTable) *PrescriptionService {
+	return &PrescriptionService{
+		prescriptionRepo: prescriptionRepo,
+		patientRepo:      patientRepo,
+		allergyRepo:      allergyRepo,
+		encounterRepo:    encounterRepo,
+		interactions:     interactions,
+	}
+}
+
+// CreatePrescription records a draft. Drafts have no clinical effect until
+// they are signed. The drug is checked against the patient's allergies and
+// active medications, and high-severity conflicts need an override reason.
+func (s *PrescriptionService) CreatePrescription(patientIdStr string, prescriptionRequest *request.PrescriptionRequest, role any, userID string) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "create_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	if err := services.CheckEncounter(s.encounterRepo, patientID, prescriptionRequest.EncounterID); err != nil {
+		return nil, err
+	}
+
+	prescription := mapper.PrescriptionToModel(patientID, prescriptionRequest, userID)
+	prescription.InteractionWarnings, err = s.checkInteractions(patientID, 0, prescription.Drug, prescription.OverrideReason)
+	if err != nil {
+		return nil, err
+	}
+
+	prescription, err = s.prescriptionRepo.CreatePrescription(prescription)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.PrescriptionToResponse(prescription), nil
+}
+
+// ListPrescriptions filters by status; "active" selects signed and dispensed
+// prescriptions.
+func (s *PrescriptionService) ListPrescriptions(patientIdStr string, prescriptionQuery *request.PrescriptionQuery, role any) ([]*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	var statuses []models.PrescriptionStatus
+	switch prescriptionQuery.Status {
+	case "":
+	case "active":
+		statuses = models.ActivePrescriptionStatuses
+	default:
+		statuses = []models.PrescriptionStatus{models.PrescriptionStatus(prescriptionQuery.Status)}
+	}
+
+	prescriptions, err := s.prescriptionRepo.ListPrescriptions(patientID, statuses)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.PrescriptionsToResponse(prescriptions), nil
+}
+
+func (s *PrescriptionService) GetPrescriptionById(patientIdStr, prescriptionIdStr string, role any) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	prescription, err := s.getPrescription(patientID, prescriptionIdStr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.PrescriptionToResponse(prescription), nil
+}
+
+// UpdatePrescriptionById edits a draft. Signed prescriptions are locked.
+func (s *PrescriptionService) UpdatePrescriptionById(patientIdStr, prescriptionIdStr string, updateRequest *request.PrescriptionUpdateRequest, role any) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "create_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	prescription, err := s.getPrescription(patientID, prescriptionIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if prescription.Status != models.PrescriptionDraft {
+		return nil, errors.New("prescription is locked")
+	}
+
+	updates := mapper.PrescriptionUpdateToColumns(updateRequest)
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	drug, drugChanged := updates["drug"].(string)
+	drugChanged = drugChanged && drug != prescription.Drug
+	if drugChanged || updateRequest.OverrideReason != nil {
+		if !drugChanged {
+			drug = prescription.Drug
+		}
+		overrideReason := ""
+		if updateRequest.OverrideReason != nil {
+			overrideReason = strings.TrimSpace(*updateRequest.OverrideReason)
+		}
+		warnings, err := s.checkInteractions(patientID, prescription.ID, drug, overrideReason)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(warnings)
+		if err != nil {
+			return nil, err
+		}
+		updates["interaction_warnings"] = string(encoded)
+		updates["override_reason"] = overrideReason
+	}
+
+	updated, err := s.prescriptionRepo.UpdatePrescriptionById(patientID, prescription.ID, []models.PrescriptionStatus{models.PrescriptionDraft}, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// signed since it was read
+			return nil, errors.New("prescription is locked")
+		}
+		return nil, err
+	}
+	return mapper.PrescriptionToResponse(updated), nil
+}
+
+// DeletePrescriptionById discards a draft. Signed prescriptions are part of
+// the medical record and can only be discontinued.
+func (s *PrescriptionService) DeletePrescriptionById(patientIdStr, prescriptionIdStr string, role any) error {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "create_prescription")
+	if err != nil {
+		return err
+	}
+	patientID := patient.ID
+
+	prescription, err := s.getPrescription(patientID, prescriptionIdStr)
+	if err != nil {
+		return err
+	}
+	if prescription.Status != models.PrescriptionDraft {
+		return errors.New("prescription is locked")
+	}
+
+	if err := s.prescriptionRepo.DeleteDraftPrescriptionById(patientID, prescription.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("prescription is locked")
+		}
+		return err
+	}
+	return nil
+}
+
+// SignPrescription signs a draft, locking it from further edits. Only roles
+// holding sign_prescription, i.e. doctors, may sign. Interactions are checked
+// again first, since allergies and other prescriptions may have been signed
+// since the draft was written; high-severity conflicts need the draft to
+// carry an override reason.
+func (s *PrescriptionService) SignPrescription(patientIdStr, prescriptionIdStr string, role any, userID string) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "sign_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	return s.transition(patientID, prescriptionIdStr, models.PrescriptionSigned, map[string]interface{}{
+		"signed_by": userID,
+		"signed_at": time.Now(),
+	})
+}
+
+func (s *PrescriptionService) DispensePrescription(patientIdStr, prescriptionIdStr string, role any, userID string) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "dispense_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	return s.transition(patientID, prescriptionIdStr, models.PrescriptionDispensed, map[string]interface{}{
+		"dispensed_by": userID,
+		"dispensed_at": time.Now(),
+	})
+}
+
+func (s *PrescriptionService) DiscontinuePrescription(patientIdStr, prescriptionIdStr string, discontinueRequest *request.DiscontinuePrescriptionRequest, role any, userID string) (*response.PrescriptionResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "discontinue_prescription")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	return s.transition(patientID, prescriptionIdStr, models.PrescriptionDiscontinued, map[string]interface{}{
+		"discontinued_by":     userID,
+		"discontinued_at":     time.Now(),
+		"discontinued_reason": discontinueRequest.Reason,
+	})
+}
+
+// transition moves the prescription to next, applying updates alongside the
+// status change. The write is conditional on the status read, so two
+// concurrent transitions cannot both succeed.
+func (s *PrescriptionService) transition(patientID uint, prescriptionIdStr string, next models.PrescriptionStatus, updates map[string]interface{}) (*response.PrescriptionResponse, error) {
+	prescription, err := s.getPrescription(patientID, prescriptionIdStr)
+	if err != nil {
+		return nil, err
+	}
+	if !prescription.Status.CanTransitionTo(next) {
+		return nil, errors.New("invalid status transition")
+	}
+
+	if next == models.PrescriptionSigned {
+		warnings, err := s.checkInteractions(patientID, prescription.ID, prescription.Drug, prescription.OverrideReason)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(warnings)
+		if err != nil {
+			return nil, err
+		}
+		updates["interaction_warnings"] = string(encoded)
+	}
+
+	updates["status"] = next
+	updated, err := s.prescriptionRepo.UpdatePrescriptionById(patientID, prescription.ID, []models.PrescriptionStatus{prescription.Status}, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid status transition")
+		}
+		return nil, err
+	}
+	return mapper.PrescriptionToResponse(updated), nil
+}
+
+// checkInteractions checks drug against the patient's relevant allergies and
+// active medications other than excludeID, and fails with an
+// InteractionConflictError when a high-severity conflict is not overridden.
+func (s *PrescriptionService) checkInteractions(patientID, excludeID uint, drug, overrideReason string) ([]models.InteractionWarning, error) {
+	allergies, err := s.allergyRepo.ListAllergies(patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.prescriptionRepo.ListPrescriptions(patientID, models.ActivePrescriptionStatuses)
+	if err != nil {
+		return nil, err
+	}
+	medications := make([]models.Prescription, 0, len(active))
+	for _, medication := range active {
+		if medication.ID != excludeID {
+			medications = append(medications, medication)
+		}
+	}
+
+	warnings := s.interactions.Check(drug, allergies, medications)
+	if HasHighSeverity(warnings) && overrideReason == "" {
+		return nil, &InteractionConflictError{Warnings: mapper.InteractionWarningsToResponse(warnings)}
+	}
+	return warnings, nil
+}
+
+func (s *PrescriptionService) getPrescription(patientID uint, prescriptionIdStr string) (*models.Prescription, error) {
+	id, err := strconv.ParseUint(prescriptionIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid prescription ID")
+	}
+
+	prescription, err := s.prescriptionRepo.GetPrescriptionById(patientID, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("prescription not found")
+		}
+		return nil, err
+	}
+	return prescription, nil
+}
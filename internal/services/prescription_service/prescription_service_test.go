@@ -0,0 +1,370 @@
+package prescription_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	allergymocks "github.com/palashbhasme/healthcare-portal/internal/services/allergy_service/mocks"
+	encountermocks "github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/prescription_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreatePrescription_StartsAsDraft(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+
+	prescriptionRepo.On("CreatePrescription", mock.MatchedBy(func(p *models.Prescription) bool {
+		return p.Status == models.PrescriptionDraft && p.PrescribedBy == "7" && p.Drug == "Amoxicillin"
+	})).Return(&models.Prescription{ID: 3, PatientID: 1, Drug: "Amoxicillin", Status: models.PrescriptionDraft, PrescribedBy: "7"}, nil)
+
+	prescriptionRequest := &request.PrescriptionRequest{Drug: "Amoxicillin", Dose: "500 mg", Route: "oral", Frequency: "three times daily"}
+	prescription, err := service.CreatePrescription("1", prescriptionRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "draft", prescription.Status)
+	assert.Empty(t, prescription.Warnings)
+}
+
+func TestCreatePrescription_AllergyConflictNeedsOverride(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{
+		{ID: 1, PatientID: 1, Substance: "Penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyConfirmed},
+	}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+
+	prescriptionRequest := &request.PrescriptionRequest{Drug: "Amoxicillin", Dose: "500 mg", Route: "oral", Frequency: "three times daily"}
+	_, err = service.CreatePrescription("1", prescriptionRequest, "doctor", "7")
+
+	var conflictErr *InteractionConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Len(t, conflictErr.Warnings, 1)
+	assert.Equal(t, "drug-allergy", conflictErr.Warnings[0].Type)
+	assert.Equal(t, "high", conflictErr.Warnings[0].Severity)
+	prescriptionRepo.AssertNotCalled(t, "CreatePrescription", mock.Anything)
+}
+
+func TestCreatePrescription_OverrideStoresReasonAndWarnings(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).
+		Return([]models.Prescription{{ID: 2, PatientID: 1, Drug: "Warfarin", Status: models.PrescriptionDispensed}}, nil)
+	prescriptionRepo.On("CreatePrescription", mock.MatchedBy(func(p *models.Prescription) bool {
+		return p.OverrideReason == "Short course, INR monitored" && len(p.InteractionWarnings) == 1 &&
+			p.InteractionWarnings[0].Conflict == "Warfarin" && p.InteractionWarnings[0].Severity == models.InteractionHigh
+	})).Return(&models.Prescription{ID: 4, PatientID: 1, Drug: "Ibuprofen", Status: models.PrescriptionDraft,
+		OverrideReason:      "Short course, INR monitored",
+		InteractionWarnings: []models.InteractionWarning{{Type: models.InteractionDrugDrug, Severity: models.InteractionHigh, Conflict: "Warfarin"}},
+	}, nil)
+
+	prescriptionRequest := &request.PrescriptionRequest{Drug: "Ibuprofen", Dose: "400 mg", Route: "oral", Frequency: "as needed",
+		OverrideReason: " Short course, INR monitored "}
+	prescription, err := service.CreatePrescription("1", prescriptionRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Short course, INR monitored", prescription.OverrideReason)
+	assert.Len(t, prescription.Warnings, 1)
+}
+
+func TestUpdatePrescriptionById_DrugChangeIsChecked(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Drug: "Paracetamol", Status: models.PrescriptionDraft}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{
+		{ID: 1, PatientID: 1, Substance: "Amoxicillin", Severity: models.AllergyModerate, VerificationStatus: models.AllergyUnconfirmed},
+	}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+
+	drug := "Ampicillin"
+	_, err = service.UpdatePrescriptionById("1", "3", &request.PrescriptionUpdateRequest{Drug: &drug}, "doctor")
+
+	var conflictErr *InteractionConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	prescriptionRepo.AssertNotCalled(t, "UpdatePrescriptionById", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignPrescription_ReceptionistDenied(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	_, err = service.SignPrescription("1", "3", "receptionist", "4")
+
+	assert.EqualError(t, err, "permission denied")
+	prescriptionRepo.AssertNotCalled(t, "UpdatePrescriptionById", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDispensePrescription_DoctorDenied(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	_, err = service.DispensePrescription("1", "3", "doctor", "7")
+
+	assert.EqualError(t, err, "permission denied")
+	prescriptionRepo.AssertNotCalled(t, "UpdatePrescriptionById", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDispensePrescription_Pharmacist(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionSigned, SignedBy: "7"}, nil)
+	prescriptionRepo.On("UpdatePrescriptionById", uint(1), uint(3), []models.PrescriptionStatus{models.PrescriptionSigned},
+		mock.MatchedBy(func(updates map[string]interface{}) bool {
+			return updates["status"] == models.PrescriptionDispensed && updates["dispensed_by"] == "8"
+		})).Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionDispensed, SignedBy: "7"}, nil)
+
+	prescription, err := service.DispensePrescription("1", "3", "pharmacist", "8")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dispensed", prescription.Status)
+	prescriptionRepo.AssertExpectations(t)
+}
+
+func TestSignPrescription_SignsDraft(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionDraft}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+	prescriptionRepo.On("UpdatePrescriptionById", uint(1), uint(3), []models.PrescriptionStatus{models.PrescriptionDraft},
+		mock.MatchedBy(func(updates map[string]interface{}) bool {
+			return updates["status"] == models.PrescriptionSigned && updates["signed_by"] == "7" && updates["signed_at"] != nil
+		})).Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionSigned, SignedBy: "7"}, nil)
+
+	prescription, err := service.SignPrescription("1", "3", "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "signed", prescription.Status)
+	assert.Equal(t, "7", prescription.SignedBy)
+}
+
+func TestSignPrescription_AlreadySigned(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	signedAt := time.Now()
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionSigned, SignedAt: &signedAt}, nil)
+
+	_, err = service.SignPrescription("1", "3", "doctor", "7")
+
+	assert.EqualError(t, err, "invalid status transition")
+}
+
+func TestSignPrescription_RecheckedAgainstSignedDrugs(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+
+	// written while warfarin was still a draft, so no warning was raised then
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Drug: "Ibuprofen", Status: models.PrescriptionDraft}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).
+		Return([]models.Prescription{{ID: 2, PatientID: 1, Drug: "Warfarin", Status: models.PrescriptionSigned}}, nil)
+
+	_, err = service.SignPrescription("1", "3", "doctor", "7")
+
+	var conflictErr *InteractionConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	prescriptionRepo.AssertNotCalled(t, "UpdatePrescriptionById", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignPrescription_StoredOverrideAccepted(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Drug: "Amoxicillin", Status: models.PrescriptionDraft, OverrideReason: "Tolerated before"}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{
+		{ID: 1, PatientID: 1, Substance: "Penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyConfirmed},
+	}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+	prescriptionRepo.On("UpdatePrescriptionById", uint(1), uint(3), []models.PrescriptionStatus{models.PrescriptionDraft},
+		mock.MatchedBy(func(updates map[string]interface{}) bool {
+			warnings, ok := updates["interaction_warnings"].(string)
+			return ok && warnings != "null" && updates["status"] == models.PrescriptionSigned
+		})).Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionSigned}, nil)
+
+	_, err = service.SignPrescription("1", "3", "doctor", "7")
+
+	assert.NoError(t, err)
+}
+
+func TestSignPrescription_LosesRace(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, allergyRepo, new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionDraft}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).Return([]models.Prescription{}, nil)
+	prescriptionRepo.On("UpdatePrescriptionById", uint(1), uint(3), mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err = service.SignPrescription("1", "3", "doctor", "7")
+
+	assert.EqualError(t, err, "invalid status transition")
+}
+
+func TestUpdatePrescriptionById_SignedIsLocked(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionSigned}, nil)
+
+	dose := "250 mg"
+	_, err = service.UpdatePrescriptionById("1", "3", &request.PrescriptionUpdateRequest{Dose: &dose}, "doctor")
+
+	assert.EqualError(t, err, "prescription is locked")
+	prescriptionRepo.AssertNotCalled(t, "UpdatePrescriptionById", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdatePrescriptionById_EditsDraft(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionDraft}, nil)
+	prescriptionRepo.On("UpdatePrescriptionById", uint(1), uint(3), []models.PrescriptionStatus{models.PrescriptionDraft}, map[string]interface{}{"dose": "250 mg"}).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Dose: "250 mg", Status: models.PrescriptionDraft}, nil)
+
+	dose := "250 mg"
+	prescription, err := service.UpdatePrescriptionById("1", "3", &request.PrescriptionUpdateRequest{Dose: &dose}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "250 mg", prescription.Dose)
+}
+
+func TestDiscontinuePrescription_DraftCannotBeDiscontinued(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("GetPrescriptionById", uint(1), uint(3)).
+		Return(&models.Prescription{ID: 3, PatientID: 1, Status: models.PrescriptionDraft}, nil)
+
+	_, err = service.DiscontinuePrescription("1", "3", &request.DiscontinuePrescriptionRequest{Reason: "rash"}, "doctor", "7")
+
+	assert.EqualError(t, err, "invalid status transition")
+}
+
+func TestListPrescriptions_Active(t *testing.T) {
+	interactions, err := LoadInteractionTable("")
+	assert.NoError(t, err)
+
+	prescriptionRepo := new(mocks.MockPrescriptionRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewPrescriptionService(prescriptionRepo, patientRepo, new(allergymocks.MockAllergyRepository), new(encountermocks.MockEncounterRepository), interactions)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).
+		Return([]models.Prescription{{ID: 3, PatientID: 1, Status: models.PrescriptionSigned}}, nil)
+
+	prescriptions, err := service.ListPrescriptions("1", &request.PrescriptionQuery{Status: "active"}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, prescriptions, 1)
+}
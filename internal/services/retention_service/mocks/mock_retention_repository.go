@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) CreateRetentionRun(run *models.RetentionRun) (*models.RetentionRun, error) {
+	args := m.Called(run)
+	if err := args.Error(0); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (m *MockRetentionRepository) ListRetentionRuns(limit int) ([]models.RetentionRun, error) {
+	args := m.Called(limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.RetentionRun), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
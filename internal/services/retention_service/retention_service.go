@@ -0,0 +1,147 @@
+package retention_service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+)
+
+const (
+	// SchedulerActor is recorded as the trigger of scheduled runs and as the
+	// author of the patient versions they write.
+	SchedulerActor = "retention-scheduler"
+	runHistorySize = 20
+)
+
+// Rule names as they appear in run reports.
+const (
+	RuleInactivePatients = "inactive_patients"
+	RuleArchivedPatients = "archived_patients"
+	RuleAuditLogs        = "audit_logs"
+)
+
+type RetentionService struct {
+	patientRepo     repository.PatientRepository
+	auditRepo       repository.AuditRepository
+	retentionRepo   repository.RetentionRepository
+	retentionConfig *config.RetentionConfig
+}
+
+func NewRetentionService(patientRepo repository.PatientRepository, auditRepo repository.AuditRepository,
+	retentionRepo repository.RetentionRepository, retentionConfig *config.RetentionConfig) *RetentionService {
+	return &RetentionService{
+		patientRepo:     patientRepo,
+		auditRepo:       auditRepo,
+		retentionRepo:   retentionRepo,
+		retentionConfig: retentionConfig,
+	}
+}
+
+// Run applies every enabled retention rule and stores the report. In a dry run
+// nothing is changed and the report lists what the rules would affect. A
+// failing rule is recorded in its result and does not stop the others.
+func (s *RetentionService) Run(dryRun bool, triggeredBy string) (*models.RetentionRun, error) {
+	run := &models.RetentionRun{DryRun: dryRun, TriggeredBy: triggeredBy, StartedAt: time.Now().UTC()}
+	now := run.StartedAt
+
+	if years := s.retentionConfig.InactivePatientYears; years > 0 {
+		run.Results = append(run.Results, s.applyPatientRule(RuleInactivePatients, models.RetentionAnonymise,
+			now.AddDate(-years, 0, 0), s.patientRepo.ListInactivePatientIDs,
+			func(id uint) error { return s.patientRepo.AnonymisePatientById(id, triggeredBy) }, dryRun))
+	}
+
+	run.Results = append(run.Results, s.applyPatientRule(RuleArchivedPatients, models.RetentionPurge,
+		now.Add(-s.retentionConfig.PatientRetention), s.patientRepo.ListArchivedPatientIDs,
+		s.patientRepo.PurgePatientById, dryRun))
+
+	if years := s.retentionConfig.AuditLogRetentionYears; years > 0 {
+		run.Results = append(run.Results, s.applyAuditRule(now.AddDate(-years, 0, 0), dryRun))
+	}
+
+	run.FinishedAt = time.Now().UTC()
+	return s.retentionRepo.CreateRetentionRun(run)
+}
+
+// DryRun reports what the retention rules would purge or anonymise right now.
+func (s *RetentionService) DryRun(role any, userID string) (*response.RetentionRunResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "manage_retention"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	run, err := s.Run(true, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RetentionRunToResponse(run), nil
+}
+
+// ListRuns returns the reports of the most recent runs, newest first.
+func (s *RetentionService) ListRuns(role any) ([]*response.RetentionRunResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "manage_retention"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	runs, err := s.retentionRepo.ListRetentionRuns(runHistorySize)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RetentionRunsToResponse(runs), nil
+}
+
+func (s *RetentionService) applyPatientRule(rule string, action models.RetentionAction, cutoff time.Time,
+	list func(cutoff time.Time) ([]uint, error), apply func(id uint) error, dryRun bool) models.RetentionRuleResult {
+	result := models.RetentionRuleResult{Rule: rule, Action: action, Cutoff: cutoff}
+
+	ids, err := list(cutoff)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if dryRun {
+		result.PatientIDs = ids
+		result.Affected = int64(len(ids))
+		return result
+	}
+
+	for _, id := range ids {
+		if err := apply(id); err != nil {
+			result.Error = fmt.Sprintf("patient %d: %s", id, err)
+			break
+		}
+		result.PatientIDs = append(result.PatientIDs, id)
+		result.Affected++
+	}
+	return result
+}
+
+func (s *RetentionService) applyAuditRule(cutoff time.Time, dryRun bool) models.RetentionRuleResult {
+	result := models.RetentionRuleResult{Rule: RuleAuditLogs, Action: models.RetentionPurge, Cutoff: cutoff}
+
+	var err error
+	if dryRun {
+		result.Affected, err = s.auditRepo.CountAuditEventsBefore(cutoff)
+	} else {
+		result.Affected, err = s.auditRepo.PurgeAuditEventsBefore(cutoff)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
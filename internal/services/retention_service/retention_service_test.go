@@ -0,0 +1,138 @@
+package retention_service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/config"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	auditMocks "github.com/palashbhasme/healthcare-portal/internal/services/audit_service/mocks"
+	patientMocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/retention_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var allRules = &config.RetentionConfig{
+	PatientRetention:       30 * 24 * time.Hour,
+	InactivePatientYears:   7,
+	AuditLogRetentionYears: 6,
+}
+
+func TestRun_DryRunChangesNothing(t *testing.T) {
+	patientRepo := new(patientMocks.MockPatientRepository)
+	auditRepo := new(auditMocks.MockAuditRepository)
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(patientRepo, auditRepo, retentionRepo, allRules)
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+	patientRepo.On("ListInactivePatientIDs", mock.Anything).Return([]uint{4, 5}, nil)
+	patientRepo.On("ListArchivedPatientIDs", mock.Anything).Return([]uint{9}, nil)
+	auditRepo.On("CountAuditEventsBefore", mock.Anything).Return(int64(120), nil)
+
+	run, err := service.Run(true, "7")
+
+	assert.NoError(t, err)
+	assert.True(t, run.DryRun)
+	assert.Equal(t, "7", run.TriggeredBy)
+	assert.Equal(t, []models.RetentionRuleResult{
+		{Rule: RuleInactivePatients, Action: models.RetentionAnonymise, Cutoff: run.StartedAt.AddDate(-7, 0, 0), Affected: 2, PatientIDs: []uint{4, 5}},
+		{Rule: RuleArchivedPatients, Action: models.RetentionPurge, Cutoff: run.StartedAt.Add(-30 * 24 * time.Hour), Affected: 1, PatientIDs: []uint{9}},
+		{Rule: RuleAuditLogs, Action: models.RetentionPurge, Cutoff: run.StartedAt.AddDate(-6, 0, 0), Affected: 120},
+	}, run.Results)
+	patientRepo.AssertNotCalled(t, "AnonymisePatientById", mock.Anything, mock.Anything)
+	patientRepo.AssertNotCalled(t, "PurgePatientById", mock.Anything)
+	auditRepo.AssertNotCalled(t, "PurgeAuditEventsBefore", mock.Anything)
+}
+
+func TestRun_AppliesRules(t *testing.T) {
+	patientRepo := new(patientMocks.MockPatientRepository)
+	auditRepo := new(auditMocks.MockAuditRepository)
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(patientRepo, auditRepo, retentionRepo, allRules)
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+	patientRepo.On("ListInactivePatientIDs", mock.Anything).Return([]uint{4}, nil)
+	patientRepo.On("AnonymisePatientById", uint(4), SchedulerActor).Return(nil)
+	patientRepo.On("ListArchivedPatientIDs", mock.Anything).Return([]uint{9}, nil)
+	patientRepo.On("PurgePatientById", uint(9)).Return(nil)
+	auditRepo.On("PurgeAuditEventsBefore", mock.Anything).Return(int64(3), nil)
+
+	run, err := service.Run(false, SchedulerActor)
+
+	assert.NoError(t, err)
+	assert.False(t, run.DryRun)
+	assert.Len(t, run.Results, 3)
+	assert.Equal(t, int64(1), run.Results[0].Affected)
+	assert.Equal(t, int64(1), run.Results[1].Affected)
+	assert.Equal(t, int64(3), run.Results[2].Affected)
+	patientRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+	retentionRepo.AssertExpectations(t)
+}
+
+func TestRun_DisabledRulesAreSkipped(t *testing.T) {
+	patientRepo := new(patientMocks.MockPatientRepository)
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(patientRepo, new(auditMocks.MockAuditRepository), retentionRepo, &config.RetentionConfig{PatientRetention: time.Hour})
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+	patientRepo.On("ListArchivedPatientIDs", mock.Anything).Return([]uint{}, nil)
+
+	run, err := service.Run(false, SchedulerActor)
+
+	assert.NoError(t, err)
+	assert.Len(t, run.Results, 1)
+	assert.Equal(t, RuleArchivedPatients, run.Results[0].Rule)
+	patientRepo.AssertNotCalled(t, "ListInactivePatientIDs", mock.Anything)
+}
+
+func TestRun_FailingRuleIsReported(t *testing.T) {
+	patientRepo := new(patientMocks.MockPatientRepository)
+	auditRepo := new(auditMocks.MockAuditRepository)
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(patientRepo, auditRepo, retentionRepo, allRules)
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+	patientRepo.On("ListInactivePatientIDs", mock.Anything).Return(nil, errors.New("db down"))
+	patientRepo.On("ListArchivedPatientIDs", mock.Anything).Return([]uint{9, 10}, nil)
+	patientRepo.On("PurgePatientById", uint(9)).Return(nil)
+	patientRepo.On("PurgePatientById", uint(10)).Return(errors.New("locked"))
+	auditRepo.On("PurgeAuditEventsBefore", mock.Anything).Return(int64(0), nil)
+
+	run, err := service.Run(false, SchedulerActor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "db down", run.Results[0].Error)
+	assert.Equal(t, "patient 10: locked", run.Results[1].Error)
+	assert.Equal(t, []uint{9}, run.Results[1].PatientIDs)
+	assert.Empty(t, run.Results[2].Error)
+}
+
+func TestDryRun_AdminOnly(t *testing.T) {
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(new(patientMocks.MockPatientRepository), new(auditMocks.MockAuditRepository), retentionRepo, allRules)
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+
+	result, err := service.DryRun("receptionist", "2")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListRuns(t *testing.T) {
+	retentionRepo := new(mocks.MockRetentionRepository)
+	service := NewRetentionService(new(patientMocks.MockPatientRepository), new(auditMocks.MockAuditRepository), retentionRepo, allRules)
+
+	retentionRepo.On("CreateRetentionRun", mock.Anything).Return(nil)
+	retentionRepo.On("ListRetentionRuns", runHistorySize).Return([]models.RetentionRun{{ID: 3, DryRun: true}}, nil)
+
+	runs, err := service.ListRuns("admin")
+
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, uint(3), runs[0].ID)
+	assert.True(t, runs[0].DryRun)
+}
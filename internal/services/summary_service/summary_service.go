@@ -0,0 +1,96 @@
+package summary_service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+// SummaryService assembles the expanded patient summary from the patient
+// record and the clinical data recorded against it.
+type SummaryService struct {
+	patientRepo      repository.PatientRepository
+	allergyRepo      repository.AllergyRepository
+	conditionRepo    repository.ConditionRepository
+	prescriptionRepo repository.PrescriptionRepository
+}
+
+func NewSummaryService(patientRepo repository.PatientRepository,
+	allergyRepo repository.AllergyRepository,
+	conditionRepo repository.ConditionRepository,
+	prescriptionRepo repository.PrescriptionRepository) *SummaryService {
+	return &SummaryService{
+		patientRepo:      patientRepo,
+		allergyRepo:      allergyRepo,
+		conditionRepo:    conditionRepo,
+		prescriptionRepo: prescriptionRepo,
+	}
+}
+
+// GetPatientSummary requires view_patient. Each clinical section is included
+// only when the role also holds that section's view permission. Refuted and
+// entered-in-error allergies are left out, and medications are the signed and
+// dispensed prescriptions.
+func (s *SummaryService) GetPatientSummary(idStr string, role any) (*response.PatientSummaryResponse, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return nil, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, "view_patient"); err != nil {
+		return nil, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid patient ID")
+	}
+
+	patient, err := s.patientRepo.GetPatientById(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("patient not found")
+		}
+		return nil, err
+	}
+
+	summary := &response.PatientSummaryResponse{Patient: services.PatientToResponse(patient, roleValue)}
+
+	if services.CheckPermission(roleValue, "view_allergy") == nil {
+		allergies, err := s.allergyRepo.ListAllergies(patient.ID)
+		if err != nil {
+			return nil, err
+		}
+		relevant := make([]models.Allergy, 0, len(allergies))
+		for _, allergy := range allergies {
+			if allergy.IsRelevant() {
+				relevant = append(relevant, allergy)
+			}
+		}
+		summary.Allergies = mapper.AllergiesToResponse(relevant)
+	}
+
+	if services.CheckPermission(roleValue, "view_condition") == nil {
+		conditions, err := s.conditionRepo.ListConditions(patient.ID, models.ConditionActive)
+		if err != nil {
+			return nil, err
+		}
+		summary.ActiveConditions = mapper.ConditionsToResponse(conditions)
+	}
+
+	if services.CheckPermission(roleValue, "view_prescription") == nil {
+		prescriptions, err := s.prescriptionRepo.ListPrescriptions(patient.ID, models.ActivePrescriptionStatuses)
+		if err != nil {
+			return nil, err
+		}
+		summary.Medications = mapper.PrescriptionsToResponse(prescriptions)
+	}
+
+	return summary, nil
+}
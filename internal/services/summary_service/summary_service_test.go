@@ -0,0 +1,71 @@
+package summary_service
+
+import (
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	allergymocks "github.com/palashbhasme/healthcare-portal/internal/services/allergy_service/mocks"
+	conditionmocks "github.com/palashbhasme/healthcare-portal/internal/services/condition_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	prescriptionmocks "github.com/palashbhasme/healthcare-portal/internal/services/prescription_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetPatientSummary_Doctor(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	conditionRepo := new(conditionmocks.MockConditionRepository)
+	prescriptionRepo := new(prescriptionmocks.MockPrescriptionRepository)
+	service := NewSummaryService(patientRepo, allergyRepo, conditionRepo, prescriptionRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, FirstName: "Jane", MedicalHistory: "Asthma as a child"}, nil)
+	allergyRepo.On("ListAllergies", uint(1)).Return([]models.Allergy{
+		{ID: 1, PatientID: 1, Substance: "Penicillin", Severity: models.AllergySevere, VerificationStatus: models.AllergyConfirmed},
+		{ID: 2, PatientID: 1, Substance: "Latex", Severity: models.AllergyMild, VerificationStatus: models.AllergyRefuted},
+	}, nil)
+	conditionRepo.On("ListConditions", uint(1), models.ConditionActive).
+		Return([]models.Condition{{ID: 4, PatientID: 1, Code: "I10", Status: models.ConditionActive}}, nil)
+	prescriptionRepo.On("ListPrescriptions", uint(1), models.ActivePrescriptionStatuses).
+		Return([]models.Prescription{{ID: 5, PatientID: 1, Drug: "Amlodipine", Status: models.PrescriptionSigned}}, nil)
+
+	summary, err := service.GetPatientSummary("1", "doctor")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", summary.Patient.FirstName)
+	assert.Equal(t, "Asthma as a child", *summary.Patient.MedicalHistory)
+	assert.Len(t, summary.Allergies, 1)
+	assert.Equal(t, "Penicillin", summary.Allergies[0].Substance)
+	assert.Len(t, summary.ActiveConditions, 1)
+	assert.Len(t, summary.Medications, 1)
+}
+
+func TestGetPatientSummary_ReceptionistSeesNoClinicalSections(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	allergyRepo := new(allergymocks.MockAllergyRepository)
+	conditionRepo := new(conditionmocks.MockConditionRepository)
+	prescriptionRepo := new(prescriptionmocks.MockPrescriptionRepository)
+	service := NewSummaryService(patientRepo, allergyRepo, conditionRepo, prescriptionRepo)
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, FirstName: "Jane"}, nil)
+
+	summary, err := service.GetPatientSummary("1", "receptionist")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", summary.Patient.FirstName)
+	assert.Nil(t, summary.Allergies)
+	assert.Nil(t, summary.ActiveConditions)
+	assert.Nil(t, summary.Medications)
+	allergyRepo.AssertNotCalled(t, "ListAllergies", uint(1))
+}
+
+func TestGetPatientSummary_PatientNotFound(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewSummaryService(patientRepo, new(allergymocks.MockAllergyRepository), new(conditionmocks.MockConditionRepository), new(prescriptionmocks.MockPrescriptionRepository))
+
+	patientRepo.On("GetPatientById", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.GetPatientSummary("9", "doctor")
+
+	assert.EqualError(t, err, "patient not found")
+}
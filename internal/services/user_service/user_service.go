@@ -1,14 +1,18 @@
 package user_service
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/patch"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
 	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
 	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
 	"github.com/palashbhasme/healthcare-portal/utils"
+	"gorm.io/gorm"
 )
 
 type UserService struct {
@@ -21,8 +25,18 @@ func NewUserService(userRepo repository.UserRepository) *UserService {
 	}
 }
 
+// CreateUser registers a doctor or receptionist. Admins are created with the
+// create-admin command, never through signup.
 func (s *UserService) CreateUser(userRequest *request.UserRequest) (*response.UserResponse, error) {
-	user := mapper.UserToModel(userRequest)
+	return s.createUser(mapper.UserToModel(userRequest))
+}
+
+// CreateAdmin registers an admin account.
+func (s *UserService) CreateAdmin(username, password string) (*response.UserResponse, error) {
+	return s.createUser(&models.User{Username: username, Password: password, Role: models.Admin})
+}
+
+func (s *UserService) createUser(user *models.User) (*response.UserResponse, error) {
 	exists, err := s.userRepo.CheckUserExists(user.Username)
 	if err != nil {
 		return nil, err
@@ -58,12 +72,27 @@ func (s *UserService) GetUserByID(idStr string) (*response.UserResponse, error)
 	return userResponse, nil
 }
 
-func (s *UserService) UpdateUserById(idStr string, updates map[string]interface{}) (*response.UserResponse, error) {
+// UpdateUserById applies a partial update. Users may only change their own
+// account unless they are an admin, and new passwords are hashed before they
+// are stored.
+func (s *UserService) UpdateUserById(idStr string, updateRequest *request.UserUpdateRequest, role any, userID string) (*response.UserResponse, error) {
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID")
 	}
 
+	if role != string(models.Admin) && idStr != userID {
+		return nil, fmt.Errorf("permission denied")
+	}
+
+	updates, err := s.userUpdateColumns(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
 	user, err := s.userRepo.UpdateUserById(uint(id), updates)
 	if err != nil {
 		return nil, err
@@ -72,6 +101,71 @@ func (s *UserService) UpdateUserById(idStr string, updates map[string]interface{
 	return userResponse, nil
 }
 
+// PatchUserById applies a JSON Patch or JSON Merge Patch to the user. The
+// patch is evaluated against the locked row, so test operations and the
+// resulting update happen atomically.
+func (s *UserService) PatchUserById(idStr string, p patch.Patch, role any, userID string) (*response.UserResponse, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if role != string(models.Admin) && idStr != userID {
+		return nil, fmt.Errorf("permission denied")
+	}
+
+	user, err := s.userRepo.PatchUserById(uint(id), func(current *models.User) (map[string]interface{}, error) {
+		document := mapper.UserToDocument(current)
+		patched, err := p.Apply(document)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := patch.Diff(document, patched)
+		for _, value := range changes {
+			if value == nil {
+				return nil, fmt.Errorf("patched user is invalid")
+			}
+		}
+		var updateRequest request.UserUpdateRequest
+		if err := request.FromDocument(changes, &updateRequest); err != nil {
+			return nil, fmt.Errorf("patched user is invalid")
+		}
+		return s.userUpdateColumns(&updateRequest)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+	userResponse := mapper.UserToResponse(user)
+	return userResponse, nil
+}
+
+// userUpdateColumns converts the fields set on an update request to column
+// updates, checking that a new username is free and hashing a new password.
+func (s *UserService) userUpdateColumns(updateRequest *request.UserUpdateRequest) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+	if updateRequest.Username != nil {
+		exists, err := s.userRepo.CheckUserExists(*updateRequest.Username)
+		if err != nil {
+			return nil, err
+		} else if exists {
+			return nil, fmt.Errorf("user already exists")
+		}
+		updates["username"] = *updateRequest.Username
+	}
+	if updateRequest.Password != nil {
+		hashed_password, err := utils.GeneratePasswordHash(*updateRequest.Password)
+		if err != nil {
+			return nil, err
+		}
+		updates["password"] = hashed_password
+	}
+	return updates, nil
+}
+
 func (s *UserService) DeleteUserById(idStr string) error {
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
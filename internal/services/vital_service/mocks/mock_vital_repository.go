@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVitalRepository struct {
+	mock.Mock
+}
+
+func (m *MockVitalRepository) CreateVital(vital *models.Vital, alert *models.WorklistItem) (*models.Vital, error) {
+	args := m.Called(vital, alert)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Vital), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVitalRepository) ListVitals(patientID uint, filter repository.VitalFilter) ([]models.Vital, error) {
+	args := m.Called(patientID, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Vital), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockVitalRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockVitalRuleRepository) CreateVitalRule(rule *models.VitalRule) (*models.VitalRule, error) {
+	args := m.Called(rule)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.VitalRule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVitalRuleRepository) CreateVitalRules(rules []models.VitalRule) error {
+	args := m.Called(rules)
+	return args.Error(0)
+}
+
+func (m *MockVitalRuleRepository) CountVitalRules() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVitalRuleRepository) ListVitalRules(measurement string) ([]models.VitalRule, error) {
+	args := m.Called(measurement)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.VitalRule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVitalRuleRepository) UpdateVitalRuleById(id uint, updates map[string]interface{}) (*models.VitalRule, error) {
+	args := m.Called(id, updates)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.VitalRule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVitalRuleRepository) DeleteVitalRuleById(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
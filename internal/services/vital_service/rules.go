@@ -0,0 +1,169 @@
+package vital_service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+// bundledVitalRules are the default thresholds loaded when no rules exist.
+// Clinical staff tune them through the vital rules API.
+//
+//go:embed vital_rules.json
+var bundledVitalRules []byte
+
+func (s *VitalService) ListVitalRules(query *request.VitalRuleQuery, role any) ([]*response.VitalRuleResponse, error) {
+	if err := checkRole(role, "view_vitals"); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.ListVitalRules(query.Measurement)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.VitalRulesToResponse(rules), nil
+}
+
+func (s *VitalService) CreateVitalRule(ruleRequest *request.VitalRuleRequest, role any, userID string) (*response.VitalRuleResponse, error) {
+	if err := checkRole(role, "manage_vital_rules"); err != nil {
+		return nil, err
+	}
+
+	rule := mapper.VitalRuleToModel(ruleRequest, userID)
+	if err := validateVitalRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.ruleRepo.CreateVitalRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.VitalRuleToResponse(rule), nil
+}
+
+// UpdateVitalRuleById replaces the rule. Readings already flagged keep the
+// thresholds they were flagged under.
+func (s *VitalService) UpdateVitalRuleById(ruleIdStr string, ruleRequest *request.VitalRuleRequest, role any, userID string) (*response.VitalRuleResponse, error) {
+	if err := checkRole(role, "manage_vital_rules"); err != nil {
+		return nil, err
+	}
+
+	ruleID, err := parseRuleID(ruleIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := mapper.VitalRuleToModel(ruleRequest, userID)
+	if err := validateVitalRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule, err = s.ruleRepo.UpdateVitalRuleById(ruleID, mapper.VitalRuleToColumns(rule))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vital rule not found")
+		}
+		return nil, err
+	}
+	return mapper.VitalRuleToResponse(rule), nil
+}
+
+func (s *VitalService) DeleteVitalRuleById(ruleIdStr string, role any) error {
+	if err := checkRole(role, "manage_vital_rules"); err != nil {
+		return err
+	}
+
+	ruleID, err := parseRuleID(ruleIdStr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.DeleteVitalRuleById(ruleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("vital rule not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// LoadBundledVitalRules stores the default rules if there are none yet and
+// returns how many were loaded.
+func (s *VitalService) LoadBundledVitalRules() (int, error) {
+	count, err := s.ruleRepo.CountVitalRules()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	rules, err := parseVitalRules(bytes.NewReader(bundledVitalRules))
+	if err != nil {
+		return 0, err
+	}
+	if err := s.ruleRepo.CreateVitalRules(rules); err != nil {
+		return 0, err
+	}
+	return len(rules), nil
+}
+
+func parseVitalRules(r io.Reader) ([]models.VitalRule, error) {
+	var ruleRequests []request.VitalRuleRequest
+	if err := json.NewDecoder(r).Decode(&ruleRequests); err != nil {
+		return nil, err
+	}
+
+	rules := make([]models.VitalRule, 0, len(ruleRequests))
+	for i := range ruleRequests {
+		rule := mapper.VitalRuleToModel(&ruleRequests[i], "")
+		if err := validateVitalRule(rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+func validateVitalRule(rule *models.VitalRule) error {
+	if rule.Low == nil && rule.High == nil {
+		return errors.New("rule needs a low or high threshold")
+	}
+	if rule.Low != nil && rule.High != nil && *rule.Low >= *rule.High {
+		return errors.New("low must be below high")
+	}
+	if rule.MaxAgeYears != nil && *rule.MaxAgeYears <= rule.MinAgeYears {
+		return errors.New("max_age_years must be above min_age_years")
+	}
+	return nil
+}
+
+func checkRole(role any, permission string) error {
+	roleValue, ok := role.(string)
+	if !ok {
+		return errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, permission); err != nil {
+		return errors.New("permission denied")
+	}
+	return nil
+}
+
+func parseRuleID(ruleIdStr string) (uint, error) {
+	id, err := strconv.ParseUint(ruleIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid vital rule ID")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,243 @@
+package vital_service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+)
+
+// clockSkew is how far in the future a reading's timestamp may be, to allow
+// for devices whose clocks run slightly fast.
+const clockSkew = 5 * time.Minute
+
+type VitalService struct {
+	vitalRepo       repository.VitalRepository
+	ruleRepo        repository.VitalRuleRepository
+	patientRepo     repository.PatientRepository
+	appointmentRepo repository.AppointmentRepository
+	encounterRepo   repository.EncounterRepository
+}
+
+func NewVitalService(vitalRepo repository.VitalRepository, ruleRepo repository.VitalRuleRepository,
+	patientRepo repository.PatientRepository, appointmentRepo repository.AppointmentRepository,
+	encounterRepo repository.EncounterRepository) *VitalService {
+	return &VitalService{
+		vitalRepo:       vitalRepo,
+		ruleRepo:        ruleRepo,
+		patientRepo:     patientRepo,
+		appointmentRepo: appointmentRepo,
+		encounterRepo:   encounterRepo,
+	}
+}
+
+// RecordVital stores a reading and flags the measurements that break the
+// threshold rules for the patient's age. A flagged reading raises an item on
+// the assigned doctor's worklist.
+func (s *VitalService) RecordVital(patientIdStr string, vitalRequest *request.VitalRequest, role any, userID string) (*response.VitalResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "record_vitals")
+	if err != nil {
+		return nil, err
+	}
+
+	vital, err := mapper.VitalToModel(patient.ID, vitalRequest, userID)
+	if err != nil {
+		return nil, errors.New("invalid recorded_at")
+	}
+	if err := validateVital(vital); err != nil {
+		return nil, err
+	}
+	if err := services.CheckEncounter(s.encounterRepo, patient.ID, vital.EncounterID); err != nil {
+		return nil, err
+	}
+
+	var heights []models.Vital
+	if vital.WeightKg != nil && vital.HeightCm == nil {
+		heights, err = s.vitalRepo.ListVitals(patient.ID, repository.VitalFilter{Type: models.VitalHeight})
+		if err != nil {
+			return nil, err
+		}
+	}
+	bmi := vital.ComputeBMI(heights)
+
+	rules, err := s.ruleRepo.ListVitalRules("")
+	if err != nil {
+		return nil, err
+	}
+	vital.Flags = flagVital(vital, bmi, patient.AgeAt(vital.RecordedAt), rules)
+
+	var alert *models.WorklistItem
+	if len(vital.Flags) > 0 {
+		doctorID, err := s.assignedDoctor(patient.ID, vital.RecordedAt, userID)
+		if err != nil {
+			return nil, err
+		}
+		alert = &models.WorklistItem{
+			DoctorID:  doctorID,
+			PatientID: patient.ID,
+			Kind:      models.WorklistAbnormalVitals,
+			Summary:   summarizeFlags(vital.Flags),
+			Status:    models.WorklistOpen,
+		}
+	}
+
+	vital, err = s.vitalRepo.CreateVital(vital, alert)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.VitalToResponse(vital, bmi), nil
+}
+
+// ListVitals returns the patient's readings oldest first so they can be
+// plotted as a time series. Readings with a weight carry a BMI computed from
+// the height taken with them or, failing that, the latest earlier height.
+func (s *VitalService) ListVitals(patientIdStr string, query *request.VitalQuery, role any) ([]*response.VitalResponse, error) {
+	patient, err := services.AuthorizePatient(s.patientRepo, patientIdStr, role, "view_vitals")
+	if err != nil {
+		return nil, err
+	}
+	patientID := patient.ID
+
+	filter := repository.VitalFilter{Type: models.VitalType(query.Type)}
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return nil, errors.New("invalid time range")
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errors.New("invalid time range")
+	}
+
+	vitals, err := s.vitalRepo.ListVitals(patientID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var heights []models.Vital
+	if filter.Type == "" || filter.Type == models.VitalWeight || filter.Type == models.VitalBMI {
+		heights, err = s.vitalRepo.ListVitals(patientID, repository.VitalFilter{Type: models.VitalHeight, To: filter.To})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vitalResponses := make([]*response.VitalResponse, 0, len(vitals))
+	for i := range vitals {
+		bmi := vitals[i].ComputeBMI(heights)
+		if filter.Type == models.VitalBMI && bmi == nil {
+			continue
+		}
+		vitalResponses = append(vitalResponses, mapper.VitalToResponse(&vitals[i], bmi))
+	}
+	return vitalResponses, nil
+}
+
+// assignedDoctor picks the doctor to alert about a reading taken at t: the
+// doctor of the patient's latest appointment starting by t, else of their
+// next one. Patients with no appointments fall back to the recording doctor.
+func (s *VitalService) assignedDoctor(patientID uint, t time.Time, userID string) (uint, error) {
+	appointments, err := s.appointmentRepo.ListAppointments(repository.AppointmentFilter{
+		PatientID: patientID,
+		Status:    models.AppointmentScheduled,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// appointments are ordered by start time
+	var latest, next uint
+	for _, appointment := range appointments {
+		if !appointment.StartTime.After(t) {
+			latest = appointment.DoctorID
+			continue
+		}
+		next = appointment.DoctorID
+		break
+	}
+	if latest != 0 {
+		return latest, nil
+	}
+	if next != 0 {
+		return next, nil
+	}
+
+	recorder, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return 0, errors.New("no doctor to alert")
+	}
+	return uint(recorder), nil
+}
+
+func validateVital(vital *models.Vital) error {
+	if !vital.HasMeasurements() {
+		return errors.New("no measurements recorded")
+	}
+	if (vital.SystolicBP == nil) != (vital.DiastolicBP == nil) {
+		return errors.New("blood pressure needs systolic and diastolic")
+	}
+	if vital.SystolicBP != nil && *vital.DiastolicBP >= *vital.SystolicBP {
+		return errors.New("diastolic must be below systolic")
+	}
+	if vital.RecordedAt.After(time.Now().Add(clockSkew)) {
+		return errors.New("recorded_at is in the future")
+	}
+	return nil
+}
+
+// flagVital checks each measurement in vital, and bmi when known, against
+// the rules covering a patient of the given age.
+func flagVital(vital *models.Vital, bmi *float64, age int, rules []models.VitalRule) []models.VitalFlag {
+	values := vital.Measurements()
+	if bmi != nil {
+		values[models.MeasurementBMI] = *bmi
+	}
+
+	var flags []models.VitalFlag
+	for i := range rules {
+		rule := &rules[i]
+		value, ok := values[rule.Measurement]
+		if !ok || !rule.AppliesToAge(age) || !rule.IsAbnormal(value) {
+			continue
+		}
+		flags = append(flags, models.VitalFlag{
+			Measurement: rule.Measurement,
+			Value:       value,
+			Low:         rule.Low,
+			High:        rule.High,
+			RuleID:      rule.ID,
+			Description: rule.Description,
+		})
+	}
+	return flags
+}
+
+// summarizeFlags describes flags in one line for a worklist item, e.g.
+// "Abnormal vitals: spo2 88 (below 92)".
+func summarizeFlags(flags []models.VitalFlag) string {
+	parts := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		if flag.Low != nil && flag.Value < *flag.Low {
+			parts = append(parts, fmt.Sprintf("%s %g (below %g)", flag.Measurement, flag.Value, *flag.Low))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %g (above %g)", flag.Measurement, flag.Value, *flag.High))
+		}
+	}
+	return "Abnormal vitals: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,243 @@
+package vital_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	appointmentmocks "github.com/palashbhasme/healthcare-portal/internal/services/appointment_service/mocks"
+	encountermocks "github.com/palashbhasme/healthcare-portal/internal/services/encounter_service/mocks"
+	patientmocks "github.com/palashbhasme/healthcare-portal/internal/services/patient_service/mocks"
+	"github.com/palashbhasme/healthcare-portal/internal/services/vital_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestRecordVital_StoresReading(t *testing.T) {
+	vitalRepo := new(mocks.MockVitalRepository)
+	ruleRepo := new(mocks.MockVitalRuleRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(vitalRepo, ruleRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	ruleRepo.On("ListVitalRules", "").Return([]models.VitalRule{}, nil)
+	vitalRepo.On("CreateVital", mock.MatchedBy(func(v *models.Vital) bool {
+		return v.PatientID == 1 && *v.SystolicBP == 120 && *v.DiastolicBP == 80 && v.RecordedBy == "7" &&
+			v.RecordedAt.Equal(time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)) && len(v.Flags) == 0
+	}), (*models.WorklistItem)(nil)).Return(&models.Vital{ID: 3, PatientID: 1, SystolicBP: intPtr(120), DiastolicBP: intPtr(80)}, nil)
+
+	vitalRequest := &request.VitalRequest{SystolicBP: intPtr(120), DiastolicBP: intPtr(80), RecordedAt: "2025-03-01T09:30:00Z"}
+	vitalResponse, err := service.RecordVital("1", vitalRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), vitalResponse.ID)
+	assert.Nil(t, vitalResponse.BMI)
+}
+
+func TestRecordVital_Validation(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	tests := map[string]struct {
+		request *request.VitalRequest
+		err     string
+	}{
+		"empty":           {&request.VitalRequest{}, "no measurements recorded"},
+		"half a pressure": {&request.VitalRequest{SystolicBP: intPtr(120)}, "blood pressure needs systolic and diastolic"},
+		"inverted":        {&request.VitalRequest{SystolicBP: intPtr(80), DiastolicBP: intPtr(120)}, "diastolic must be below systolic"},
+		"future":          {&request.VitalRequest{HeartRate: intPtr(70), RecordedAt: time.Now().Add(time.Hour).Format(time.RFC3339)}, "recorded_at is in the future"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.RecordVital("1", tc.request, "doctor", "7")
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRecordVital_ReceptionistDenied(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	_, err := service.RecordVital("1", &request.VitalRequest{HeartRate: intPtr(70)}, "receptionist", "4")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListVitals_CarriesHeightForwardForBMI(t *testing.T) {
+	vitalRepo := new(mocks.MockVitalRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(vitalRepo, new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	jan := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	heights := []models.Vital{{ID: 2, HeightCm: floatPtr(180), RecordedAt: feb}}
+
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{Type: models.VitalWeight}).Return([]models.Vital{
+		{ID: 1, WeightKg: floatPtr(85), RecordedAt: jan},
+		{ID: 3, WeightKg: floatPtr(81), RecordedAt: mar},
+	}, nil)
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{Type: models.VitalHeight}).Return(heights, nil)
+
+	vitals, err := service.ListVitals("1", &request.VitalQuery{Type: "weight"}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, vitals, 2)
+	assert.Nil(t, vitals[0].BMI)
+	assert.Equal(t, 25.0, *vitals[1].BMI)
+}
+
+func TestListVitals_BMISkipsReadingsWithoutHeight(t *testing.T) {
+	vitalRepo := new(mocks.MockVitalRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(vitalRepo, new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{Type: models.VitalBMI}).Return([]models.Vital{
+		{ID: 1, WeightKg: floatPtr(85)},
+		{ID: 2, WeightKg: floatPtr(70), HeightCm: floatPtr(170)},
+	}, nil)
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{Type: models.VitalHeight}).Return([]models.Vital{}, nil)
+
+	vitals, err := service.ListVitals("1", &request.VitalQuery{Type: "bmi"}, "doctor")
+
+	assert.NoError(t, err)
+	assert.Len(t, vitals, 1)
+	assert.Equal(t, 24.2, *vitals[0].BMI)
+}
+
+func TestListVitals_InvalidRange(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	_, err := service.ListVitals("1", &request.VitalQuery{From: "2025-03-01T00:00:00Z", To: "2025-02-01T00:00:00Z"}, "doctor")
+
+	assert.EqualError(t, err, "invalid time range")
+}
+
+func adultRules() []models.VitalRule {
+	return []models.VitalRule{
+		{ID: 1, Measurement: models.MeasurementSpO2, Low: floatPtr(92)},
+		{ID: 2, Measurement: models.MeasurementSystolicBP, MaxAgeYears: intPtr(18), Low: floatPtr(90), High: floatPtr(140)},
+		{ID: 3, Measurement: models.MeasurementSystolicBP, MinAgeYears: 18, Low: floatPtr(90), High: floatPtr(160)},
+		{ID: 4, Measurement: models.MeasurementBMI, MinAgeYears: 18, Low: floatPtr(16), High: floatPtr(40)},
+	}
+}
+
+func TestRecordVital_AbnormalAlertsAssignedDoctor(t *testing.T) {
+	vitalRepo := new(mocks.MockVitalRepository)
+	ruleRepo := new(mocks.MockVitalRuleRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	service := NewVitalService(vitalRepo, ruleRepo, patientRepo, appointmentRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	recordedAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	ruleRepo.On("ListVitalRules", "").Return(adultRules(), nil)
+	appointmentRepo.On("ListAppointments", repository.AppointmentFilter{PatientID: 1, Status: models.AppointmentScheduled}).Return([]models.Appointment{
+		{DoctorID: 4, StartTime: recordedAt.AddDate(0, -2, 0)},
+		{DoctorID: 5, StartTime: recordedAt.Add(-time.Hour)},
+		{DoctorID: 6, StartTime: recordedAt.AddDate(0, 1, 0)},
+	}, nil)
+	vitalRepo.On("CreateVital", mock.MatchedBy(func(v *models.Vital) bool {
+		return len(v.Flags) == 2 && v.Flags[0].RuleID == 1 && v.Flags[1].RuleID == 3
+	}), mock.MatchedBy(func(item *models.WorklistItem) bool {
+		return item.DoctorID == 5 && item.PatientID == 1 && item.Status == models.WorklistOpen &&
+			item.Summary == "Abnormal vitals: spo2 88 (below 92), systolic_bp 170 (above 160)"
+	})).Return(&models.Vital{ID: 8, PatientID: 1}, nil)
+
+	vitalRequest := &request.VitalRequest{SpO2: intPtr(88), SystolicBP: intPtr(170), DiastolicBP: intPtr(95), RecordedAt: "2025-03-01T09:30:00Z"}
+	_, err := service.RecordVital("1", vitalRequest, "doctor", "7")
+
+	assert.NoError(t, err)
+	vitalRepo.AssertExpectations(t)
+}
+
+func TestRecordVital_AlertFallsBackToRecorder(t *testing.T) {
+	vitalRepo := new(mocks.MockVitalRepository)
+	ruleRepo := new(mocks.MockVitalRuleRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	appointmentRepo := new(appointmentmocks.MockAppointmentRepository)
+	service := NewVitalService(vitalRepo, ruleRepo, patientRepo, appointmentRepo, new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	ruleRepo.On("ListVitalRules", "").Return(adultRules(), nil)
+	appointmentRepo.On("ListAppointments", mock.Anything).Return([]models.Appointment{}, nil)
+	vitalRepo.On("ListVitals", uint(1), repository.VitalFilter{Type: models.VitalHeight}).
+		Return([]models.Vital{{HeightCm: floatPtr(160)}}, nil)
+	vitalRepo.On("CreateVital", mock.MatchedBy(func(v *models.Vital) bool {
+		return len(v.Flags) == 1 && v.Flags[0].Measurement == models.MeasurementBMI && v.Flags[0].Value == 42.6
+	}), mock.MatchedBy(func(item *models.WorklistItem) bool {
+		return item.DoctorID == 7
+	})).Return(&models.Vital{ID: 8, PatientID: 1, WeightKg: floatPtr(109)}, nil)
+
+	_, err := service.RecordVital("1", &request.VitalRequest{WeightKg: floatPtr(109)}, "doctor", "7")
+
+	assert.NoError(t, err)
+	vitalRepo.AssertExpectations(t)
+}
+
+func TestFlagVital_UsesAgeBand(t *testing.T) {
+	vital := &models.Vital{SystolicBP: intPtr(150), DiastolicBP: intPtr(90)}
+
+	assert.Len(t, flagVital(vital, nil, 15, adultRules()), 1)
+	assert.Empty(t, flagVital(vital, nil, 40, adultRules()))
+}
+
+func TestBundledVitalRulesAreValid(t *testing.T) {
+	ruleRepo := new(mocks.MockVitalRuleRepository)
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), ruleRepo, patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+	ruleRepo.On("CountVitalRules").Return(int64(0), nil)
+	ruleRepo.On("CreateVitalRules", mock.Anything).Return(nil)
+
+	loaded, err := service.LoadBundledVitalRules()
+
+	assert.NoError(t, err)
+	assert.Greater(t, loaded, 0)
+}
+
+func TestCreateVitalRule_Validation(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	_, err := service.CreateVitalRule(&request.VitalRuleRequest{Measurement: "heart_rate"}, "doctor", "7")
+	assert.EqualError(t, err, "rule needs a low or high threshold")
+
+	_, err = service.CreateVitalRule(&request.VitalRuleRequest{Measurement: "heart_rate", Low: floatPtr(120), High: floatPtr(50)}, "doctor", "7")
+	assert.EqualError(t, err, "low must be below high")
+
+	_, err = service.CreateVitalRule(&request.VitalRuleRequest{Measurement: "heart_rate", MinAgeYears: 18, MaxAgeYears: intPtr(12), Low: floatPtr(50)}, "doctor", "7")
+	assert.EqualError(t, err, "max_age_years must be above min_age_years")
+}
+
+func TestCreateVitalRule_ReceptionistDenied(t *testing.T) {
+	patientRepo := new(patientmocks.MockPatientRepository)
+	service := NewVitalService(new(mocks.MockVitalRepository), new(mocks.MockVitalRuleRepository), patientRepo, new(appointmentmocks.MockAppointmentRepository), new(encountermocks.MockEncounterRepository))
+
+	patientRepo.On("GetPatientById", uint(1)).Return(&models.Patient{ID: 1, DOB: time.Date(1985, 6, 1, 0, 0, 0, 0, time.UTC)}, nil)
+
+	_, err := service.CreateVitalRule(&request.VitalRuleRequest{Measurement: "spo2", Low: floatPtr(90)}, "receptionist", "4")
+
+	assert.EqualError(t, err, "permission denied")
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+)
+
+// TimeRange is a half-open interval [Start, End).
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// WorkingWindows expands the weekly template into concrete windows for every
+// day of loc that touches [from, to). Working hours are wall-clock times in
+// the clinic's zone, so the same template yields the same instants whatever
+// zone the caller's timestamps are in.
+func WorkingWindows(hours []models.WorkingHours, from, to time.Time, loc *time.Location) []TimeRange {
+	windows := []TimeRange{}
+	from = from.In(loc)
+
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, h := range hours {
+			if h.Weekday != day.Weekday() {
+				continue
+			}
+
+			start, err := atClock(day, h.StartTime)
+			if err != nil {
+				continue
+			}
+			end, err := atClock(day, h.EndTime)
+			if err != nil {
+				continue
+			}
+			windows = append(windows, TimeRange{Start: start, End: end})
+		}
+	}
+	return windows
+}
+
+// CheckWorkingHours verifies that [start, end) lies inside one of the doctor's
+// working-hours windows and is not blocked by leave or a holiday.
+func CheckWorkingHours(availabilityRepo repository.AvailabilityRepository, doctorID uint, start, end time.Time, loc *time.Location) error {
+	hours, err := availabilityRepo.GetWorkingHours(doctorID)
+	if err != nil {
+		return err
+	}
+
+	inside := false
+	for _, w := range WorkingWindows(hours, start, end, loc) {
+		if !start.Before(w.Start) && !end.After(w.End) {
+			inside = true
+			break
+		}
+	}
+	if !inside {
+		return errors.New("outside working hours")
+	}
+
+	exceptions, err := availabilityRepo.ListExceptions(doctorID, start, end)
+	if err != nil {
+		return err
+	}
+	if len(exceptions) > 0 {
+		return errors.New("doctor unavailable")
+	}
+	return nil
+}
+
+func atClock(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWorklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorklistRepository) ListWorklistItems(doctorID uint, status models.WorklistItemStatus) ([]models.WorklistItem, error) {
+	args := m.Called(doctorID, status)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WorklistItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorklistRepository) AcknowledgeWorklistItem(doctorID, id uint, at time.Time) (*models.WorklistItem, error) {
+	args := m.Called(doctorID, id, at)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.WorklistItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
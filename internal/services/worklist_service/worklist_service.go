@@ -0,0 +1,80 @@
+package worklist_service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/mapper"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/response"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/repository"
+	"github.com/palashbhasme/healthcare-portal/internal/services"
+	"gorm.io/gorm"
+)
+
+type WorklistService struct {
+	worklistRepo repository.WorklistRepository
+}
+
+func NewWorklistService(worklistRepo repository.WorklistRepository) *WorklistService {
+	return &WorklistService{
+		worklistRepo: worklistRepo,
+	}
+}
+
+func (s *WorklistService) ListWorklist(doctorIdStr string, query *request.WorklistQuery, role any, userID string) ([]*response.WorklistItemResponse, error) {
+	doctorID, err := authorize(doctorIdStr, role, userID, "view_worklist")
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.worklistRepo.ListWorklistItems(doctorID, models.WorklistItemStatus(query.Status))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.WorklistItemsToResponse(items), nil
+}
+
+func (s *WorklistService) AcknowledgeWorklistItem(doctorIdStr, itemIdStr string, role any, userID string) (*response.WorklistItemResponse, error) {
+	doctorID, err := authorize(doctorIdStr, role, userID, "update_worklist")
+	if err != nil {
+		return nil, err
+	}
+
+	itemID, err := strconv.ParseUint(itemIdStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid worklist item ID")
+	}
+
+	item, err := s.worklistRepo.AcknowledgeWorklistItem(doctorID, uint(itemID), time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("worklist item not found")
+		}
+		return nil, err
+	}
+	return mapper.WorklistItemToResponse(item), nil
+}
+
+// authorize restricts doctors to their own worklist.
+func authorize(doctorIdStr string, role any, userID, permission string) (uint, error) {
+	roleValue, ok := role.(string)
+	if !ok {
+		return 0, errors.New("invalid role type")
+	}
+
+	if err := services.CheckPermission(roleValue, permission); err != nil {
+		return 0, errors.New("permission denied")
+	}
+
+	id, err := strconv.ParseUint(doctorIdStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid doctor ID")
+	}
+	if doctorIdStr != userID {
+		return 0, errors.New("permission denied")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,53 @@
+package worklist_service
+
+import (
+	"testing"
+
+	"github.com/palashbhasme/healthcare-portal/internal/api/dto/request"
+	"github.com/palashbhasme/healthcare-portal/internal/domain/models"
+	"github.com/palashbhasme/healthcare-portal/internal/services/worklist_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestListWorklist_OwnItems(t *testing.T) {
+	worklistRepo := new(mocks.MockWorklistRepository)
+	service := NewWorklistService(worklistRepo)
+
+	worklistRepo.On("ListWorklistItems", uint(5), models.WorklistOpen).
+		Return([]models.WorklistItem{{ID: 1, DoctorID: 5, PatientID: 2, Kind: models.WorklistAbnormalVitals, Status: models.WorklistOpen}}, nil)
+
+	items, err := service.ListWorklist("5", &request.WorklistQuery{Status: "open"}, "doctor", "5")
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "abnormal_vitals", items[0].Kind)
+}
+
+func TestListWorklist_OtherDoctorDenied(t *testing.T) {
+	service := NewWorklistService(new(mocks.MockWorklistRepository))
+
+	_, err := service.ListWorklist("5", &request.WorklistQuery{}, "doctor", "6")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestListWorklist_ReceptionistDenied(t *testing.T) {
+	service := NewWorklistService(new(mocks.MockWorklistRepository))
+
+	_, err := service.ListWorklist("5", &request.WorklistQuery{}, "receptionist", "5")
+
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestAcknowledgeWorklistItem_NotFound(t *testing.T) {
+	worklistRepo := new(mocks.MockWorklistRepository)
+	service := NewWorklistService(worklistRepo)
+
+	worklistRepo.On("AcknowledgeWorklistItem", uint(5), uint(9), mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.AcknowledgeWorklistItem("5", "9", "doctor", "5")
+
+	assert.EqualError(t, err, "worklist item not found")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/palashbhasme/healthcare-portal/config"
@@ -39,6 +40,13 @@ func main() {
 		log.Fatal("Error migrating databse", zap.Error(err))
 	}
 
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], logger, db); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	api.Server(logger, db)
 	if err != nil {
 		log.Fatal("Error starting server", zap.Error(err))